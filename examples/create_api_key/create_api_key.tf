@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    marqo = {
+      source  = "marqo-ai/marqo"
+      version = "1.2.1"
+    }
+    time = {
+      source = "hashicorp/time"
+    }
+  }
+}
+
+provider "marqo" {
+  host    = "https://controller.marqo-staging.com/api/v2"
+  api_key = var.marqo_api_key
+}
+
+# Changes every 90 days, which rotates the key
+resource "time_rotating" "search_service" {
+  rotation_days = 90
+}
+
+resource "marqo_api_key" "search_service" {
+  name             = "search-service"
+  description      = "Used by the search service"
+  rotation_trigger = time_rotating.search_service.id
+}
+
+output "search_service_api_key" {
+  value     = marqo_api_key.search_service.secret
+  sensitive = true
+}
+
+variable "marqo_api_key" {
+  type        = string
+  description = "Marqo API key"
+}
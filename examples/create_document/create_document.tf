@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    marqo = {
+      source  = "marqo-ai/marqo"
+      version = "1.2.1"
+    }
+  }
+}
+
+provider "marqo" {
+  host    = "https://controller.marqo-staging.com/api/v2"
+  api_key = var.marqo_api_key
+}
+
+resource "marqo_index" "faq" {
+  index_name = "example-faq-index"
+  settings = {
+    type                 = "unstructured"
+    model                = "hf/e5-base-v2"
+    inference_type       = "marqo.CPU.large"
+    number_of_inferences = 1
+    number_of_replicas   = 0
+    number_of_shards     = 1
+    storage_class        = "marqo.basic"
+  }
+}
+
+resource "marqo_document" "refunds" {
+  index_name  = marqo_index.faq.index_name
+  document_id = "faq-refunds"
+  document_json = jsonencode({
+    question = "How do I get a refund?"
+    answer   = "Contact support within 30 days of purchase."
+    category = "billing"
+  })
+  tensor_fields = ["question", "answer"]
+}
+
+variable "marqo_api_key" {
+  type        = string
+  description = "Marqo API key"
+}
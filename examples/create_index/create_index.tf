@@ -20,7 +20,6 @@ resource "marqo_index" "example" {
     model                             = "open_clip/ViT-L-14/laion2b_s32b_b82k"
     normalize_embeddings              = true
     inference_type                    = "marqo.CPU.small"
-    all_fields                        = []
     number_of_inferences              = 1
     number_of_replicas                = 0
     number_of_shards                  = 1
@@ -30,7 +29,6 @@ resource "marqo_index" "example" {
       split_method  = "sentence"
       split_overlap = 0
     }
-    image_preprocessing = {}
     ann_parameters = {
       space_type = "prenormalized-angular"
       parameters = {
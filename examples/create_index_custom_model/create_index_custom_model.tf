@@ -26,7 +26,6 @@ resource "marqo_index" "example" {
     }
     normalize_embeddings = false
     inference_type       = "marqo.CPU.small"
-    all_fields           = []
     number_of_inferences = 1
     number_of_replicas   = 0
     number_of_shards     = 1
@@ -37,7 +36,6 @@ resource "marqo_index" "example" {
       split_method  = "sentence"
       split_overlap = 0
     }
-    image_preprocessing = {}
     ann_parameters = {
       space_type = "prenormalized-angular"
       parameters = {
@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    marqo = {
+      source  = "marqo-ai/marqo"
+      version = "1.2.1"
+    }
+  }
+}
+
+provider "marqo" {
+  host    = "https://controller.marqo-staging.com/api/v2"
+  api_key = var.marqo_api_key
+}
+
+resource "marqo_index" "example" {
+  index_name = "example-semi-structured-index"
+  settings = {
+    type                 = "semi-structured"
+    model                = "hf/e5-base-v2"
+    inference_type       = "marqo.CPU.large"
+    number_of_inferences = 1
+    number_of_replicas   = 0
+    number_of_shards     = 1
+    storage_class        = "marqo.basic"
+    # Fields can be added here later without replacing the index
+    all_fields = [
+      { name = "title", type = "text", features = ["lexical_search"] },
+    ]
+    tensor_fields = ["title"]
+  }
+}
+
+output "discovered_fields" {
+  value = marqo_index.example.discovered_fields
+}
+
+variable "marqo_api_key" {
+  type        = string
+  description = "Marqo API key"
+}
@@ -42,9 +42,6 @@ resource "marqo_index" "example" {
       split_method  = "sentence"
       split_overlap = 0
     }
-    image_preprocessing = {
-      patch_method = null
-    }
     ann_parameters = {
       space_type = "prenormalized-angular"
       parameters = {
@@ -0,0 +1,40 @@
+terraform {
+  # Write-only arguments, such as data_json_wo below, need Terraform 1.11 or later
+  required_version = ">= 1.11"
+  required_providers {
+    marqo = {
+      source  = "marqo-ai/marqo"
+      version = "1.2.1"
+    }
+    vault = {
+      source  = "hashicorp/vault"
+      version = ">= 4.7"
+    }
+  }
+}
+
+provider "marqo" {
+  host    = "https://controller.marqo-staging.com/api/v2"
+  api_key = var.marqo_api_key
+}
+
+# Created for this run only and revoked when Terraform closes it
+ephemeral "marqo_ephemeral_api_key" "search_service" {
+  name_prefix = "search-service-"
+  description = "Used by the search service"
+}
+
+# The secret is written to Vault without being stored in the Terraform state
+resource "vault_kv_secret_v2" "search_service" {
+  mount = "secret"
+  name  = "search-service/marqo"
+  data_json_wo = jsonencode({
+    api_key = ephemeral.marqo_ephemeral_api_key.search_service.secret
+  })
+  data_json_wo_version = 1
+}
+
+variable "marqo_api_key" {
+  type        = string
+  description = "Marqo API key"
+}
@@ -0,0 +1,12 @@
+# Look up an index owned by another team by name
+data "marqo_index" "products" {
+  index_name = "products"
+}
+
+output "products_endpoint" {
+  value = data.marqo_index.products.marqo_endpoint
+}
+
+output "products_model" {
+  value = data.marqo_index.products.settings.model
+}
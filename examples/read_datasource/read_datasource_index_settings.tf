@@ -0,0 +1,11 @@
+# Create a staging copy of an index with the same settings
+data "marqo_index_settings" "products" {
+  index_name = "products"
+}
+
+resource "marqo_index" "products_staging" {
+  index_name = "products-staging"
+  settings = merge(data.marqo_index_settings.products.settings, {
+    number_of_replicas = 0
+  })
+}
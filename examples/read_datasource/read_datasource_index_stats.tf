@@ -0,0 +1,11 @@
+# Warn when an index is running out of storage
+check "products_storage" {
+  data "marqo_index_stats" "products" {
+    index_name = "products"
+  }
+
+  assert {
+    condition     = data.marqo_index_stats.products.storage_used_percentage < 80
+    error_message = "Index products is using ${data.marqo_index_stats.products.storage_used_percentage}% of its storage."
+  }
+}
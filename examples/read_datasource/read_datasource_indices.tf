@@ -22,4 +22,25 @@ output "indices_in_marqo_cloud" {
 variable "marqo_api_key" {
   type        = string
   description = "Marqo API key"
-}
\ No newline at end of file
+}
+# Only the READY production indexes, with numeric attributes
+data "marqo_read_indices" "production" {
+  id         = "production"
+  name_regex = "-prod$"
+  status     = "READY"
+  typed      = true
+}
+
+output "production_shards" {
+  value = { for index in data.marqo_read_indices.production.typed_items : index.index_name => index.number_of_shards }
+}
+
+# Storage utilisation of every index, for a capacity dashboard
+data "marqo_read_indices" "capacity" {
+  id            = "capacity"
+  include_stats = true
+}
+
+output "storage_used_percentage" {
+  value = { for index in data.marqo_read_indices.capacity.items : index.index_name => try(index.stats.storage_used_percentage, null) }
+}
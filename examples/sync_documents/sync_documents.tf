@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    marqo = {
+      source  = "marqo-ai/marqo"
+      version = "1.2.1"
+    }
+  }
+}
+
+provider "marqo" {
+  host    = "https://controller.marqo-staging.com/api/v2"
+  api_key = var.marqo_api_key
+}
+
+resource "marqo_index" "faq" {
+  index_name = "example-faq-index"
+  settings = {
+    type                 = "unstructured"
+    model                = "hf/e5-base-v2"
+    inference_type       = "marqo.CPU.large"
+    number_of_inferences = 1
+    number_of_replicas   = 0
+    number_of_shards     = 1
+    storage_class        = "marqo.basic"
+  }
+}
+
+resource "marqo_documents" "faq" {
+  index_name    = marqo_index.faq.index_name
+  source_file   = "${path.module}/faq.jsonl"
+  tensor_fields = ["question", "answer"]
+  batch_size    = 64
+  parallelism   = 4
+}
+
+output "document_count" {
+  value = marqo_documents.faq.document_count
+}
+
+variable "marqo_api_key" {
+  type        = string
+  description = "Marqo API key"
+}
@@ -0,0 +1,100 @@
+package go_marqo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrAPIKeyNotFound is returned by GetAPIKey when the account has no API key with the requested name.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKey is a Marqo Cloud API key. Secret is only returned when the key is created.
+type APIKey struct {
+	Name        string `json:"apiKeyName"`
+	Description string `json:"description"`
+	Secret      string `json:"apiKey,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// String redacts the secret.
+func (k APIKey) String() string {
+	return fmt.Sprintf("APIKey(name=%s, secret redacted)", k.Name)
+}
+
+// GoString redacts the secret.
+func (k APIKey) GoString() string {
+	return k.String()
+}
+
+type apiKeysResponse struct {
+	APIKeys []APIKey `json:"apiKeys"`
+}
+
+type createAPIKeyRequest struct {
+	Name        string `json:"apiKeyName"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListAPIKeys lists the API keys of the account. The secrets are not included.
+func (c *Client) ListAPIKeys() ([]APIKey, error) {
+	_, body, err := c.sendJSONRequest("GET", fmt.Sprintf("%s/api-keys", c.BaseURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	var response apiKeysResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+	return response.APIKeys, nil
+}
+
+// GetAPIKey returns the API key with the given name, or ErrAPIKeyNotFound if there is none.
+func (c *Client) GetAPIKey(name string) (APIKey, error) {
+	keys, err := c.ListAPIKeys()
+	if err != nil {
+		return APIKey{}, err
+	}
+	for _, key := range keys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+	return APIKey{}, fmt.Errorf("failed to get api key %s: %w", name, ErrAPIKeyNotFound)
+}
+
+// CreateAPIKey creates an API key and returns it together with its secret.
+func (c *Client) CreateAPIKey(name string, description string) (APIKey, error) {
+	request := createAPIKeyRequest{Name: name, Description: description}
+	_, body, err := c.sendJSONRequest("POST", fmt.Sprintf("%s/api-keys", c.BaseURL), request)
+	if err != nil {
+		return APIKey{}, fmt.Errorf("failed to create api key %s: %w", name, err)
+	}
+
+	var key APIKey
+	if err := json.Unmarshal(body, &key); err != nil {
+		return APIKey{}, fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+	if key.Secret == "" {
+		return APIKey{}, fmt.Errorf("failed to create api key %s: the response did not include the key", name)
+	}
+	if key.Name == "" {
+		key.Name = name
+	}
+	return key, nil
+}
+
+// DeleteAPIKey deletes an API key by name. A key that does not exist is ignored.
+func (c *Client) DeleteAPIKey(name string) error {
+	status, _, err := c.sendJSONRequest("DELETE", fmt.Sprintf("%s/api-keys/%s", c.BaseURL, url.PathEscape(name)), nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete api key %s: %w", name, err)
+	}
+	return nil
+}
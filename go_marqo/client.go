@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// ErrIndexAlreadyExists is returned by CreateIndex when an index with the requested name already exists.
+var ErrIndexAlreadyExists = errors.New("index already exists")
+
 type Client struct {
 	BaseURL string
 	APIKey  string
@@ -27,6 +31,7 @@ type IndexDetail struct {
 	NumberOfShards               int64                   `json:"numberOfShards"`
 	NumberOfReplicas             int64                   `json:"numberOfReplicas"`
 	IndexStatus                  string                  `json:"indexStatus"`
+	FailureReason                string                  `json:"failureReason"`
 	AllFields                    []AllFieldInput         `json:"allFields"`
 	TensorFields                 []string                `json:"tensorFields"`
 	NumberOfInferences           int64                   `json:"numberOfInferences"`
@@ -51,6 +56,28 @@ type IndexDetail struct {
 	AnnParameters                AnnParameters           `json:"annParameters"`
 	MarqoVersion                 string                  `json:"marqoVersion"`
 	FilterStringMaxLength        int64                   `json:"filterStringMaxLength"`
+
+	// Raw holds every field of the index as returned by the API, including
+	// settings that are not mapped to a field above.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes an index and keeps the raw fields in Raw.
+func (d *IndexDetail) UnmarshalJSON(data []byte) error {
+	type indexDetail IndexDetail
+	var detail indexDetail
+	if err := json.Unmarshal(data, &detail); err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*d = IndexDetail(detail)
+	d.Raw = raw
+	return nil
 }
 
 type AllFieldInput struct {
@@ -136,9 +163,12 @@ type IndexSettings struct {
 	Type                         string                  `json:"type"`
 	VectorNumericType            string                  `json:"vectorNumericType"`
 	Model                        string                  `json:"model"`
-	NormalizeEmbeddings          bool                    `json:"normalizeEmbeddings"`
+	ModelProperties              *ModelProperties        `json:"modelProperties"`
+	NormalizeEmbeddings          *bool                   `json:"normalizeEmbeddings"`
 	TextPreprocessing            TextPreprocessing       `json:"textPreprocessing"`
 	ImagePreprocessing           ImagePreprocessingModel `json:"imagePreprocessing"`
+	VideoPreprocessing           VideoPreprocessingModel `json:"videoPreprocessing"`
+	AudioPreprocessing           AudioPreprocessingModel `json:"audioPreprocessing"`
 	AnnParameters                AnnParameters           `json:"annParameters"`
 	TensorFields                 []string                `json:"tensorFields"`
 	AllFields                    []AllFieldInput         `json:"allFields"`
@@ -147,8 +177,31 @@ type IndexSettings struct {
 	StorageClass                 string                  `json:"storageClass"`
 	NumberOfShards               int64                   `json:"numberOfShards"`
 	NumberOfReplicas             int64                   `json:"numberOfReplicas"`
-	TreatUrlsAndPointersAsImages bool                    `json:"treatUrlsAndPointersAsImages"`
+	TreatUrlsAndPointersAsImages *bool                   `json:"treatUrlsAndPointersAsImages"`
+	TreatUrlsAndPointersAsMedia  *bool                   `json:"treatUrlsAndPointersAsMedia"`
 	FilterStringMaxLength        int64                   `json:"filterStringMaxLength"`
+
+	// Raw holds every setting as returned by the API, including settings
+	// that are not mapped to a field above.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes index settings and keeps the raw settings in Raw.
+func (s *IndexSettings) UnmarshalJSON(data []byte) error {
+	type indexSettings IndexSettings
+	var settings indexSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = IndexSettings(settings)
+	s.Raw = raw
+	return nil
 }
 
 // NewClient creates and returns a new API client or an error.
@@ -182,6 +235,43 @@ func NewClient(baseURL, apiKey *string) (*Client, error) {
 	return client, nil
 }
 
+// sendJSONRequest sends a JSON request and returns the response status and
+// body, with an error for a non-200 response.
+func (c *Client) sendJSONRequest(method string, requestURL string, payload interface{}) (int, []byte, error) {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to marshal request: %v", err)
+		}
+		body = bytes.NewBuffer(jsonData)
+	}
+
+	tflog.Debug(context.Background(), fmt.Sprintf("Sending %s request to: %s", method, requestURL))
+
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-KEY", c.APIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, respBody, fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return resp.StatusCode, respBody, nil
+}
+
 // ListIndices lists all indices.
 func (c *Client) ListIndices() ([]IndexDetail, error) {
 	url := fmt.Sprintf("%s/indexes", c.BaseURL)
@@ -236,10 +326,10 @@ func (c *Client) ListIndices() ([]IndexDetail, error) {
 // GetIndexSettings fetches settings for a specific index and decodes into IndexSettings model.
 func (c *Client) GetIndexSettings(indexName string) (IndexSettings, error) {
 	url := fmt.Sprintf("%s/indexes/%s/settings", c.BaseURL, indexName)
-	fmt.Println("GetIndexSettings URL: ", url)
+	tflog.Debug(context.Background(), fmt.Sprintf("Sending request to: %s", url))
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return IndexSettings{}, fmt.Errorf("API request error: %s - %v", req.URL.String(), err)
+		return IndexSettings{}, fmt.Errorf("API request error: %s - %v", url, err)
 	}
 
 	req.Header.Set("X-API-KEY", c.APIKey)
@@ -247,9 +337,13 @@ func (c *Client) GetIndexSettings(indexName string) (IndexSettings, error) {
 	if err != nil {
 		return IndexSettings{}, err
 	}
-	fmt.Println("Settings Response: ", resp)
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return IndexSettings{}, fmt.Errorf("failed to get index settings: status %d: %s", resp.StatusCode, string(body))
+	}
+
 	var settings IndexSettings
 	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
 		return IndexSettings{}, err
@@ -273,6 +367,11 @@ func (c *Client) GetIndexStats(indexName string) (IndexStats, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return IndexStats{}, fmt.Errorf("failed to get index stats: status %d: %s", resp.StatusCode, string(body))
+	}
+
 	var stats IndexStats
 	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
 		return IndexStats{}, err
@@ -307,6 +406,9 @@ func (c *Client) CreateIndex(indexName string, settings map[string]interface{})
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusConflict || strings.Contains(string(body), "already exists") {
+			return fmt.Errorf("failed to create index: %w: %s", ErrIndexAlreadyExists, string(body))
+		}
 		return fmt.Errorf("failed to create index: %s", string(body))
 	}
 
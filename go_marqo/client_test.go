@@ -2,6 +2,8 @@ package go_marqo_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -45,6 +47,28 @@ func TestListIndices(t *testing.T) {
 	assert.Equal(t, "test-index", indices[0].IndexName)
 }
 
+func TestListIndicesKeepsRawFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"results": [{"indexName": "test-index", "textChunkPrefix": "passage: "}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{
+		BaseURL: server.URL,
+		APIKey:  "test-api-key",
+	}
+
+	indices, err := client.ListIndices()
+	assert.NoError(t, err)
+	assert.Len(t, indices, 1)
+	assert.Equal(t, "test-index", indices[0].IndexName)
+	assert.Equal(t, "passage: ", indices[0].Raw["textChunkPrefix"])
+}
+
 func TestGetIndexSettings(t *testing.T) {
 	// Create a test server to mock the API response
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -67,6 +91,54 @@ func TestGetIndexSettings(t *testing.T) {
 	assert.Equal(t, "test-type", settings.Type)
 }
 
+func TestGetIndexSettingsDecodesAllSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{
+			"type": "unstructured",
+			"model": "my-model",
+			"modelProperties": {"name": "ViT-B-32", "dimensions": 512, "type": "open_clip", "modelLocation": {"hf": {"repoId": "org/model"}, "authRequired": true}},
+			"normalizeEmbeddings": false,
+			"videoPreprocessing": {"splitLength": 20, "splitOverlap": 3},
+			"audioPreprocessing": {"splitLength": 10, "splitOverlap": 2},
+			"treatUrlsAndPointersAsMedia": true,
+			"textChunkPrefix": "passage: "
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}
+
+	settings, err := client.GetIndexSettings("test-index")
+	assert.NoError(t, err)
+	if assert.NotNil(t, settings.ModelProperties) {
+		assert.Equal(t, int64(512), settings.ModelProperties.Dimensions)
+		assert.Equal(t, "org/model", settings.ModelProperties.ModelLocation.Hf.RepoId)
+	}
+	if assert.NotNil(t, settings.NormalizeEmbeddings) {
+		assert.False(t, *settings.NormalizeEmbeddings)
+	}
+	assert.Equal(t, go_marqo.VideoPreprocessingModel{SplitLength: 20, SplitOverlap: 3}, settings.VideoPreprocessing)
+	assert.Equal(t, go_marqo.AudioPreprocessingModel{SplitLength: 10, SplitOverlap: 2}, settings.AudioPreprocessing)
+	assert.Nil(t, settings.TreatUrlsAndPointersAsImages)
+	assert.Equal(t, "passage: ", settings.Raw["textChunkPrefix"])
+}
+
+func TestGetIndexSettingsReturnsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}
+
+	_, err := client.GetIndexSettings("missing-index")
+	assert.ErrorContains(t, err, "status 404")
+}
+
 func TestCreateIndex(t *testing.T) {
 	// Create a test server to mock the API response
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -112,6 +184,27 @@ func TestCreateIndex(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateIndexAlreadyExists(t *testing.T) {
+	// Create a test server that rejects the create request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, err := w.Write([]byte(`{"message": "Index test-index already exists", "code": "index_already_exists"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{
+		BaseURL: server.URL,
+		APIKey:  "test-api-key",
+	}
+
+	err := client.CreateIndex("test-index", map[string]interface{}{"type": "cpu"})
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, go_marqo.ErrIndexAlreadyExists))
+}
+
 func TestGetIndexStats(t *testing.T) {
 	// Create a test server to mock the API response
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -144,6 +237,25 @@ func TestGetIndexStats(t *testing.T) {
 	assert.Equal(t, 60.2, stats.Backend.StorageUsedPercentage)
 }
 
+func TestGetIndexStatsReturnsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"message": "Index not found"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{
+		BaseURL: server.URL,
+		APIKey:  "test-api-key",
+	}
+
+	_, err := client.GetIndexStats("missing-index")
+	assert.ErrorContains(t, err, "status 404")
+}
+
 func TestDeleteIndex(t *testing.T) {
 	// Create a test server to mock the API response
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -210,3 +322,208 @@ func TestUpdateIndex(t *testing.T) {
 	err := client.UpdateIndex("test-index", settings)
 	assert.NoError(t, err)
 }
+
+func TestAddDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/indexes/test-index/documents", r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-API-KEY"))
+
+		var request go_marqo.AddDocumentsRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, "faq-1", request.Documents[0]["_id"])
+		assert.Equal(t, []string{"answer"}, request.TensorFields)
+
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"errors": false, "items": [{"_id": "faq-1", "status": 200}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{APIKey: "test-api-key"}
+
+	response, err := client.AddDocuments(server.URL, "test-index", go_marqo.AddDocumentsRequest{
+		Documents:    []map[string]interface{}{{"_id": "faq-1", "answer": "Yes"}},
+		TensorFields: []string{"answer"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, response.Items, 1)
+}
+
+func TestAddDocumentsReportsFailedDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"errors": true, "items": [{"_id": "faq-1", "status": 400, "error": "invalid field"}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{APIKey: "test-api-key"}
+
+	_, err := client.AddDocuments(server.URL, "test-index", go_marqo.AddDocumentsRequest{
+		Documents: []map[string]interface{}{{"_id": "faq-1"}},
+	})
+	assert.ErrorContains(t, err, "faq-1 (status 400): invalid field")
+}
+
+func TestGetDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		if r.URL.Path != "/indexes/test-index/documents/faq-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"_id": "faq-1", "answer": "Yes", "views": 9007199254740993}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{APIKey: "test-api-key"}
+
+	document, err := client.GetDocument(server.URL, "test-index", "faq-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Yes", document["answer"])
+	assert.Equal(t, json.Number("9007199254740993"), document["views"])
+
+	_, err = client.GetDocument(server.URL, "test-index", "faq-2")
+	assert.True(t, errors.Is(err, go_marqo.ErrDocumentNotFound))
+}
+
+func TestUpdateDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "/indexes/test-index/documents", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"errors": false, "items": [{"_id": "faq-1", "status": 200}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{APIKey: "test-api-key"}
+
+	_, err := client.UpdateDocuments(server.URL, "test-index", []map[string]interface{}{{"_id": "faq-1", "rank": 2}})
+	assert.NoError(t, err)
+}
+
+func TestDeleteDocumentsIgnoresMissingDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/indexes/test-index/documents/delete-batch", r.URL.Path)
+
+		var ids []string
+		err := json.NewDecoder(r.Body).Decode(&ids)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"faq-1", "faq-2"}, ids)
+
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"items": [{"_id": "faq-1", "status": 200}, {"_id": "faq-2", "status": 404, "result": "not_found"}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{APIKey: "test-api-key"}
+
+	response, err := client.DeleteDocuments(server.URL, "test-index", []string{"faq-1", "faq-2"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Items, 1)
+}
+
+func TestAddDocumentsSendsModelAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"hf": map[string]interface{}{"token": "hf_secret"}}, request["modelAuth"])
+
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"errors": false, "items": []}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{APIKey: "test-api-key"}
+	auth := &go_marqo.ModelAuth{HF: &go_marqo.ModelAuthHF{Token: "hf_secret"}}
+
+	_, err := client.AddDocuments(server.URL, "test-index", go_marqo.AddDocumentsRequest{ModelAuth: auth})
+	assert.NoError(t, err)
+	assert.NotContains(t, fmt.Sprintf("%+v %#v", auth, go_marqo.AddDocumentsRequest{ModelAuth: auth}), "hf_secret")
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api-keys", r.URL.Path)
+		var request map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"apiKeyName": "search-service", "description": "Search"}, request)
+
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"apiKeyName": "search-service", "description": "Search", "apiKey": "s3cr3t", "createdAt": "2024-01-01T00:00:00Z"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}
+
+	key, err := client.CreateAPIKey("search-service", "Search")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", key.Secret)
+	assert.Equal(t, "2024-01-01T00:00:00Z", key.CreatedAt)
+	assert.NotContains(t, fmt.Sprintf("%v %+v %#v", key, key, key), "s3cr3t")
+}
+
+func TestGetAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api-keys", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"apiKeys": [{"apiKeyName": "search-service", "description": "Search"}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}
+
+	key, err := client.GetAPIKey("search-service")
+	assert.NoError(t, err)
+	assert.Equal(t, "Search", key.Description)
+
+	_, err = client.GetAPIKey("missing")
+	assert.True(t, errors.Is(err, go_marqo.ErrAPIKeyNotFound))
+}
+
+func TestDeleteAPIKeyIgnoresMissingKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		if r.URL.Path == "/api-keys/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}
+
+	assert.NoError(t, client.DeleteAPIKey("missing"))
+	assert.Error(t, client.DeleteAPIKey("broken"))
+}
@@ -0,0 +1,162 @@
+package go_marqo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrDocumentNotFound is returned by GetDocument when the index has no document with the requested ID.
+var ErrDocumentNotFound = errors.New("document not found")
+
+// AddDocumentsRequest is the body of an add documents request. Documents that already exist
+// are replaced. TensorFields must be nil for structured indexes and non-nil for the others.
+type AddDocumentsRequest struct {
+	Documents    []map[string]interface{} `json:"documents"`
+	TensorFields []string                 `json:"tensorFields"`
+	Mappings     map[string]interface{}   `json:"mappings,omitempty"`
+	ModelAuth    *ModelAuth               `json:"modelAuth,omitempty"`
+}
+
+// DocumentsResponse is the response to adding, updating or deleting documents.
+type DocumentsResponse struct {
+	Errors bool                   `json:"errors"`
+	Items  []DocumentResponseItem `json:"items"`
+}
+
+// DocumentResponseItem is the outcome for a single document.
+type DocumentResponseItem struct {
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Err returns an error describing every document that failed, or nil if none did.
+func (r DocumentsResponse) Err() error {
+	var failures []string
+	for _, item := range r.Items {
+		if item.Error != "" || item.Status >= http.StatusBadRequest {
+			failures = append(failures, fmt.Sprintf("%s (status %d): %s", item.ID, item.Status, item.Error))
+		}
+	}
+	if len(failures) == 0 {
+		if r.Errors {
+			return errors.New("the request reported errors for one or more documents")
+		}
+		return nil
+	}
+	return fmt.Errorf("documents failed: %s", strings.Join(failures, "; "))
+}
+
+// documentsURL returns the URL of an index's documents on the data plane endpoint
+// that ListIndices reports as marqoEndpoint.
+func documentsURL(endpoint string, indexName string) string {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "https://" + endpoint
+	}
+	return fmt.Sprintf("%s/indexes/%s/documents", strings.TrimSuffix(endpoint, "/"), url.PathEscape(indexName))
+}
+
+// decodeDocumentsResponse decodes a DocumentsResponse and returns an error if any document failed.
+func decodeDocumentsResponse(body []byte) (DocumentsResponse, error) {
+	var response DocumentsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return DocumentsResponse{}, fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+	return response, response.Err()
+}
+
+// AddDocuments adds documents to an index, replacing documents with the same _id.
+func (c *Client) AddDocuments(endpoint string, indexName string, request AddDocumentsRequest) (DocumentsResponse, error) {
+	_, body, err := c.sendJSONRequest("POST", documentsURL(endpoint, indexName), request)
+	if err != nil {
+		return DocumentsResponse{}, fmt.Errorf("failed to add documents: %w", err)
+	}
+	return decodeDocumentsResponse(body)
+}
+
+// UpdateDocuments partially updates documents, identified by their _id. Fields that are not
+// given keep their value; tensor fields cannot be updated this way.
+func (c *Client) UpdateDocuments(endpoint string, indexName string, documents []map[string]interface{}) (DocumentsResponse, error) {
+	payload := map[string]interface{}{"documents": documents}
+	_, body, err := c.sendJSONRequest("PATCH", documentsURL(endpoint, indexName), payload)
+	if err != nil {
+		return DocumentsResponse{}, fmt.Errorf("failed to update documents: %w", err)
+	}
+	return decodeDocumentsResponse(body)
+}
+
+// GetDocument fetches a document by _id. It returns ErrDocumentNotFound if the index
+// has no such document.
+func (c *Client) GetDocument(endpoint string, indexName string, documentID string) (map[string]interface{}, error) {
+	requestURL := documentsURL(endpoint, indexName) + "/" + url.PathEscape(documentID)
+	status, body, err := c.sendJSONRequest("GET", requestURL, nil)
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get document %s: %w", documentID, ErrDocumentNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document %s: %w", documentID, err)
+	}
+
+	// Numbers are kept as written, so integers beyond 2^53 are not rounded
+	var document map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+	return document, nil
+}
+
+// DeleteDocuments deletes documents by _id. Documents that do not exist are ignored.
+func (c *Client) DeleteDocuments(endpoint string, indexName string, documentIDs []string) (DocumentsResponse, error) {
+	_, body, err := c.sendJSONRequest("POST", documentsURL(endpoint, indexName)+"/delete-batch", documentIDs)
+	if err != nil {
+		return DocumentsResponse{}, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	var response DocumentsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return DocumentsResponse{}, fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+	var found []DocumentResponseItem
+	for _, item := range response.Items {
+		if item.Status != http.StatusNotFound {
+			found = append(found, item)
+		}
+	}
+	response.Items = found
+	return response, response.Err()
+}
+
+// ModelAuth holds the credentials Marqo uses to download a private custom model when
+// vectorising documents. It is redacted when formatted, so that it cannot leak into logs.
+type ModelAuth struct {
+	S3 *ModelAuthS3 `json:"s3,omitempty"`
+	HF *ModelAuthHF `json:"hf,omitempty"`
+}
+
+// ModelAuthS3 holds AWS credentials for a model stored in a private S3 bucket.
+type ModelAuthS3 struct {
+	AWSAccessKeyID     string `json:"aws_access_key_id"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key"`
+}
+
+// ModelAuthHF holds a Hugging Face token for a model in a private repository.
+type ModelAuthHF struct {
+	Token string `json:"token"`
+}
+
+// String redacts the credentials.
+func (a *ModelAuth) String() string {
+	return "ModelAuth(redacted)"
+}
+
+// GoString redacts the credentials.
+func (a *ModelAuth) GoString() string {
+	return a.String()
+}
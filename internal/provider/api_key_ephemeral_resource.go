@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &ephemeralAPIKey{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ephemeralAPIKey{}
+	_ ephemeral.EphemeralResourceWithClose     = &ephemeralAPIKey{}
+)
+
+// defaultEphemeralAPIKeyPrefix is the prefix of the names of ephemeral API keys.
+const defaultEphemeralAPIKeyPrefix = "terraform-ephemeral-"
+
+// ephemeralAPIKeyNameKey is the private data key that records the API key to revoke on close.
+const ephemeralAPIKeyNameKey = "api_key_name"
+
+// OpenEphemeralAPIKey is a helper function to simplify the provider implementation.
+func OpenEphemeralAPIKey() ephemeral.EphemeralResource {
+	return &ephemeralAPIKey{}
+}
+
+// ephemeralAPIKey is the ephemeral resource implementation.
+type ephemeralAPIKey struct {
+	marqoClient *go_marqo.Client
+}
+
+// EphemeralAPIKeyModel maps the ephemeral resource schema data.
+type EphemeralAPIKeyModel struct {
+	NamePrefix  types.String `tfsdk:"name_prefix"`
+	Description types.String `tfsdk:"description"`
+	Name        types.String `tfsdk:"name"`
+	Secret      types.String `tfsdk:"secret"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *ephemeralAPIKey) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*marqoResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *marqoResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.marqoClient = data.client
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *ephemeralAPIKey) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ephemeral_api_key"
+}
+
+func (e *ephemeralAPIKey) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a Marqo Cloud API key that only lasts for one Terraform run and is never written to state " +
+			"or plan files. The key is created when Terraform opens the ephemeral resource, which it does during both " +
+			"plan and apply, and is revoked when Terraform closes it at the end of the run. Use it to pass a key to " +
+			"write-only or ephemeral arguments of other providers. It always creates a new key rather than reading an " +
+			"existing one, because Marqo only returns a key's secret in the response that creates it. Requires " +
+			"Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Optional: true,
+				Description: fmt.Sprintf("The prefix of the generated key name, which is followed by a random suffix. "+
+					"Default is %q.", defaultEphemeralAPIKeyPrefix),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "A description of what the API key is used for.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The generated name of the API key. It cannot be set, since the secret of an existing key cannot be read.",
+			},
+			"secret": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key secret.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the API key was created.",
+			},
+		},
+	}
+}
+
+// generateAPIKeyName returns prefix followed by a random suffix.
+func generateAPIKeyName(prefix string) (string, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("error generating api key name: %w", err)
+	}
+	return prefix + hex.EncodeToString(suffix), nil
+}
+
+// Open creates the API key and records its name in private data so that Close can revoke it.
+func (e *ephemeralAPIKey) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model EphemeralAPIKeyModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefix := defaultEphemeralAPIKeyPrefix
+	if !model.NamePrefix.IsNull() {
+		prefix = model.NamePrefix.ValueString()
+	}
+	name, err := generateAPIKeyName(prefix)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Create API Key", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating ephemeral API key %s", name))
+	key, err := e.marqoClient.CreateAPIKey(name, model.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Create API Key", fmt.Sprintf("Could not create API key %s: %s", name, err))
+		return
+	}
+	ctx = tflog.MaskMessageStrings(ctx, key.Secret)
+
+	// Recorded before anything else can fail, so that the key is always revoked
+	recordedName, _ := json.Marshal(key.Name)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ephemeralAPIKeyNameKey, recordedName)...)
+
+	model.Name = types.StringValue(key.Name)
+	model.Secret = types.StringValue(key.Secret)
+	model.CreatedAt = optionalString(key.CreatedAt)
+	diags = resp.Result.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, fmt.Sprintf("Created ephemeral API key %s", key.Name))
+}
+
+// Close revokes the API key created by Open.
+func (e *ephemeralAPIKey) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	recordedName, diags := req.Private.GetKey(ctx, ephemeralAPIKeyNameKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(recordedName) == 0 {
+		return
+	}
+
+	var name string
+	if err := json.Unmarshal(recordedName, &name); err != nil {
+		resp.Diagnostics.AddError("Failed to Revoke API Key", fmt.Sprintf("Could not decode the API key name: %s", err))
+		return
+	}
+
+	if err := e.marqoClient.DeleteAPIKey(name); err != nil {
+		resp.Diagnostics.AddError("Failed to Revoke API Key", fmt.Sprintf("Could not delete API key %s: %s", name, err))
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Revoked ephemeral API key %s", name))
+}
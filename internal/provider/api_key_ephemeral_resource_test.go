@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccEphemeralAPIKey(t *testing.T) {
+	t.Parallel() // Enable parallel testing
+	prefix := fmt.Sprintf("donotdelete_ephemeral_%s_", randomString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		// The echo provider copies the ephemeral value into state, where the test can check it
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"marqo": providerserver.NewProtocol6WithError(New("test")()),
+			"echo":  echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralAPIKeyConfig(prefix),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("name"),
+						knownvalue.StringRegexp(regexp.MustCompile("^"+prefix))),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("secret"),
+						knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func testAccEphemeralAPIKeyConfig(prefix string) string {
+	return fmt.Sprintf(`
+		ephemeral "marqo_ephemeral_api_key" "test" {
+			name_prefix = "%s"
+			description = "Terraform acceptance test"
+		}
+
+		provider "echo" {
+			data = ephemeral.marqo_ephemeral_api_key.test
+		}
+
+		resource "echo" "test" {}
+	`, prefix)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEphemeralAPIKeyOpenAndClose(t *testing.T) {
+	ctx := context.Background()
+	standIn, client := newAPIKeyStandIn(t)
+	server := testProtocolServer(t, client.BaseURL).(tfprotov6.ProviderServerWithEphemeralResources)
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	(&ephemeralAPIKey{}).Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name_prefix": tftypes.NewValue(tftypes.String, "ci-"),
+		"description": tftypes.NewValue(tftypes.String, "Used by CI"),
+		"name":        tftypes.NewValue(tftypes.String, nil),
+		"secret":      tftypes.NewValue(tftypes.String, nil),
+		"created_at":  tftypes.NewValue(tftypes.String, nil),
+	})
+
+	openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "marqo_ephemeral_api_key",
+		Config:   testDynamicValue(t, config),
+	})
+	if err != nil || len(openResp.Diagnostics) > 0 {
+		t.Fatalf("failed to open: %v %v", err, openResp.Diagnostics)
+	}
+
+	result, err := openResp.Result.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := result.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	var name, secret string
+	_ = attributes["name"].As(&name)
+	_ = attributes["secret"].As(&secret)
+	if !strings.HasPrefix(name, "ci-") || secret != "secret-1" {
+		t.Errorf("unexpected key %s with secret %q", name, secret)
+	}
+	if key, exists := standIn.keys[name]; !exists || key.Description != "Used by CI" {
+		t.Fatalf("expected key %s to be created, got %v", name, standIn.keys)
+	}
+
+	closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "marqo_ephemeral_api_key",
+		Private:  openResp.Private,
+	})
+	if err != nil || len(closeResp.Diagnostics) > 0 {
+		t.Fatalf("failed to close: %v %v", err, closeResp.Diagnostics)
+	}
+	if _, exists := standIn.keys[name]; exists {
+		t.Errorf("expected key %s to be revoked", name)
+	}
+}
+
+func TestEphemeralAPIKeyDefaultPrefix(t *testing.T) {
+	name, err := generateAPIKeyName(defaultEphemeralAPIKeyPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(name, defaultEphemeralAPIKeyPrefix) || len(name) != len(defaultEphemeralAPIKeyPrefix)+12 {
+		t.Errorf("unexpected name %s", name)
+	}
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &apiKeyResource{}
+	_ resource.ResourceWithConfigure   = &apiKeyResource{}
+	_ resource.ResourceWithImportState = &apiKeyResource{}
+)
+
+// ManageAPIKeyResource is a helper function to simplify the provider implementation.
+func ManageAPIKeyResource() resource.Resource {
+	return &apiKeyResource{}
+}
+
+// apiKeyResource is the resource implementation.
+type apiKeyResource struct {
+	marqoClient *go_marqo.Client
+}
+
+// APIKeyResourceModel maps the resource schema data.
+type APIKeyResourceModel struct {
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+	Secret          types.String `tfsdk:"secret"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *apiKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*marqoResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *marqoResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.marqoClient = data.client
+}
+
+// Metadata returns the resource type name.
+func (r *apiKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+func (r *apiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Marqo Cloud API key. Marqo only returns the secret when a key is created, so every " +
+			"change to the key creates a new one with the same name and a new secret. The secret is stored in the " +
+			"Terraform state, so the state must be kept private; use the marqo_ephemeral_api_key ephemeral resource " +
+			"for short-lived keys that never touch state.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the API key.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"description": schema.StringAttribute{
+				Optional:      true,
+				Description:   "A description of what the API key is used for. Changing it rotates the key.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Optional: true,
+				Description: "An arbitrary value that rotates the key whenever it changes, for example a date " +
+					"from the time provider. It is not sent to Marqo.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"secret": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				Description: "The API key secret. It is null for imported keys, as Marqo does not return existing " +
+					"secrets; change rotation_trigger to get a new one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the API key was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// optionalString returns a null string for an empty value.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+func (r *apiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model APIKeyResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := model.Name.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Creating API key %s", name))
+	key, err := r.marqoClient.CreateAPIKey(name, model.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Create API Key", fmt.Sprintf("Could not create API key %s: %s", name, err))
+		return
+	}
+	ctx = tflog.MaskMessageStrings(ctx, key.Secret)
+
+	model.Secret = types.StringValue(key.Secret)
+	model.CreatedAt = optionalString(key.CreatedAt)
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	tflog.Info(ctx, fmt.Sprintf("Created API key %s", name))
+}
+
+func (r *apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state APIKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	key, err := r.marqoClient.GetAPIKey(name)
+	if errors.Is(err, go_marqo.ErrAPIKeyNotFound) {
+		resp.Diagnostics.AddWarning(
+			"Resource Not Found",
+			fmt.Sprintf("API key %s no longer exists. The state will be deleted.", name))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Get API Key", fmt.Sprintf("Could not get API key %s: %s", name, err))
+		return
+	}
+
+	state.Description = optionalString(key.Description)
+	state.CreatedAt = optionalString(key.CreatedAt)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only stores the plan, as every attribute that is sent to Marqo requires replacement.
+func (r *apiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model APIKeyResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *apiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state APIKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	if err := r.marqoClient.DeleteAPIKey(name); err != nil {
+		resp.Diagnostics.AddError("Failed to Delete API Key", fmt.Sprintf("Could not delete API key %s: %s", name, err))
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Deleted API key %s", name))
+}
+
+// ImportState imports an API key by name. The secret is not returned by Marqo, so it is null
+// until the key is rotated.
+func (r *apiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceAPIKey(t *testing.T) {
+	t.Parallel() // Enable parallel testing
+	key_name := fmt.Sprintf("donotdelete_key_%s", randomString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the key
+			{
+				Config: testAccResourceAPIKeyConfig(key_name, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("marqo_api_key.test", "name", key_name),
+					resource.TestCheckResourceAttrSet("marqo_api_key.test", "secret"),
+				),
+			},
+			// Changing rotation_trigger creates a new secret
+			{
+				Config: testAccResourceAPIKeyConfig(key_name, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("marqo_api_key.test", "rotation_trigger", "second"),
+					resource.TestCheckResourceAttrSet("marqo_api_key.test", "secret"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:                         "marqo_api_key.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        key_name,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"secret", "rotation_trigger"},
+			},
+		},
+	})
+}
+
+func testAccResourceAPIKeyConfig(name string, trigger string) string {
+	return fmt.Sprintf(`
+		resource "marqo_api_key" "test" {
+			name             = "%s"
+			description      = "Terraform acceptance test"
+			rotation_trigger = "%s"
+		}
+	`, name, trigger)
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// apiKeyStandIn emulates the Marqo Cloud API key endpoints.
+type apiKeyStandIn struct {
+	mu      sync.Mutex
+	keys    map[string]go_marqo.APIKey
+	created int
+}
+
+func newAPIKeyStandIn(t *testing.T) (*apiKeyStandIn, *go_marqo.Client) {
+	t.Helper()
+	standIn := &apiKeyStandIn{keys: map[string]go_marqo.APIKey{}}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	return standIn, &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}
+}
+
+func (s *apiKeyStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/api-keys/")
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/api-keys":
+		response := map[string][]go_marqo.APIKey{"apiKeys": {}}
+		for _, key := range s.keys {
+			key.Secret = ""
+			response["apiKeys"] = append(response["apiKeys"], key)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	case r.Method == "POST" && r.URL.Path == "/api-keys":
+		var request struct {
+			Name        string `json:"apiKeyName"`
+			Description string `json:"description"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, exists := s.keys[request.Name]; exists {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.created++
+		key := go_marqo.APIKey{
+			Name:        request.Name,
+			Description: request.Description,
+			Secret:      fmt.Sprintf("secret-%d", s.created),
+			CreatedAt:   "2024-01-01T00:00:00Z",
+		}
+		s.keys[key.Name] = key
+		_ = json.NewEncoder(w).Encode(key)
+	case r.Method == "DELETE" && name != r.URL.Path:
+		if _, exists := s.keys[name]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.keys, name)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func apiKeyState(ctx context.Context, t *testing.T, r *apiKeyResource, model *APIKeyResourceModel) tfsdk.State {
+	t.Helper()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if model != nil {
+		if diags := state.Set(ctx, model); diags.HasError() {
+			t.Fatalf("failed to build state: %v", diags)
+		}
+	}
+	return state
+}
+
+func TestAPIKeyResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	standIn, client := newAPIKeyStandIn(t)
+	r := &apiKeyResource{marqoClient: client}
+
+	planned := APIKeyResourceModel{
+		Name:            types.StringValue("search-service"),
+		Description:     types.StringValue("Search"),
+		RotationTrigger: types.StringValue("2024-01"),
+		Secret:          types.StringUnknown(),
+		CreatedAt:       types.StringUnknown(),
+	}
+	plan := apiKeyState(ctx, t, r, &planned)
+	createResp := &resource.CreateResponse{State: apiKeyState(ctx, t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+
+	var created APIKeyResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Secret.ValueString() != "secret-1" || created.CreatedAt.ValueString() != "2024-01-01T00:00:00Z" {
+		t.Errorf("unexpected created key: %+v", created)
+	}
+
+	// The secret is kept, as Marqo does not return it again
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	var read APIKeyResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Secret.ValueString() != "secret-1" || read.RotationTrigger.ValueString() != "2024-01" {
+		t.Errorf("unexpected key after read: %+v", read)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", deleteResp.Diagnostics)
+	}
+	if len(standIn.keys) != 0 {
+		t.Errorf("expected the key to be deleted, got %v", standIn.keys)
+	}
+
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Errorf("expected a deleted key to be removed from state")
+	}
+}
+
+func TestAPIKeyResourceImport(t *testing.T) {
+	ctx := context.Background()
+	standIn, client := newAPIKeyStandIn(t)
+	standIn.keys["search-service"] = go_marqo.APIKey{Name: "search-service", Description: "Search", Secret: "secret-0"}
+	r := &apiKeyResource{marqoClient: client}
+
+	importResp := &resource.ImportStateResponse{State: apiKeyState(ctx, t, r, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "search-service"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", importResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+
+	var state APIKeyResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.Description.ValueString() != "Search" || !state.Secret.IsNull() {
+		t.Errorf("unexpected imported key: %+v", state)
+	}
+}
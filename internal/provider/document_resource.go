@@ -0,0 +1,502 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"marqo/go_marqo"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &documentResource{}
+	_ resource.ResourceWithConfigure      = &documentResource{}
+	_ resource.ResourceWithImportState    = &documentResource{}
+	_ resource.ResourceWithValidateConfig = &documentResource{}
+)
+
+// ManageDocumentResource is a helper function to simplify the provider implementation.
+func ManageDocumentResource() resource.Resource {
+	return &documentResource{}
+}
+
+// documentResource is the resource implementation.
+type documentResource struct {
+	marqoClient *go_marqo.Client
+	modelAuth   *go_marqo.ModelAuth
+}
+
+// DocumentResourceModel maps the resource schema data.
+type DocumentResourceModel struct {
+	IndexName     types.String         `tfsdk:"index_name"`
+	DocumentID    types.String         `tfsdk:"document_id"`
+	DocumentJSON  jsontypes.Normalized `tfsdk:"document_json"`
+	TensorFields  []string             `tfsdk:"tensor_fields"`
+	MappingsJSON  jsontypes.Normalized `tfsdk:"mappings_json"`
+	MarqoEndpoint types.String         `tfsdk:"marqo_endpoint"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *documentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*marqoResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *marqoResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.marqoClient = data.client
+	r.modelAuth = data.modelAuth
+}
+
+// Metadata returns the resource type name.
+func (r *documentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_document"
+}
+
+func (r *documentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single document in a Marqo index.",
+		Attributes: map[string]schema.Attribute{
+			"index_name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the index the document belongs to.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"document_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The _id of the document.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"document_json": schema.StringAttribute{
+				Required:   true,
+				CustomType: jsontypes.NormalizedType{},
+				Description: "The fields of the document as a JSON object. An _id field, if present, must match document_id. " +
+					"Fields beginning with an underscore that Marqo adds are ignored when detecting changes, " +
+					"and formatting changes are applied without touching the document.",
+			},
+			"tensor_fields": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The fields to vectorise, for indexes that are not structured. Changing it adds the document again.",
+			},
+			"mappings_json": schema.StringAttribute{
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
+				Description: "Marqo field mappings as a JSON object, for example multimodal_combination fields. " +
+					"Changing it adds the document again; formatting changes are ignored.",
+			},
+			"marqo_endpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Marqo endpoint of the index that serves the document.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that document_json and mappings_json are JSON objects and that
+// document_json does not name a different _id.
+func (r *documentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DocumentResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.DocumentJSON.IsNull() && !config.DocumentJSON.IsUnknown() {
+		document, err := parseJSONObject("document_json", config.DocumentJSON.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("document_json"), "Invalid Document JSON", err.Error())
+		} else if id, exists := document["_id"]; exists && !config.DocumentID.IsUnknown() && id != config.DocumentID.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("document_json"),
+				"Invalid Document JSON",
+				fmt.Sprintf("The _id in document_json (%v) does not match document_id %q.", id, config.DocumentID.ValueString()))
+		}
+	}
+
+	if !config.MappingsJSON.IsNull() && !config.MappingsJSON.IsUnknown() {
+		if _, err := parseJSONObject("mappings_json", config.MappingsJSON.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("mappings_json"), "Invalid Mappings JSON", err.Error())
+		}
+	}
+}
+
+// documentFields returns the fields of a document fetched from Marqo without the _id and
+// the other underscore fields Marqo adds, so that it can be compared with document_json.
+func documentFields(fetched map[string]interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, len(fetched))
+	for key, value := range fetched {
+		if !strings.HasPrefix(key, "_") {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// refreshDocumentJSON returns document_json as fetched from the index. The prior value is kept
+// when it is semantically equal, so that formatting differences are not reported.
+func refreshDocumentJSON(prior jsontypes.Normalized, fetched map[string]interface{}) (jsontypes.Normalized, error) {
+	fields := documentFields(fetched)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorFields, err := parseJSONObject("document_json", prior.ValueString())
+		if err == nil && jsonEqual(documentFields(priorFields), fields) {
+			return prior, nil
+		}
+	}
+
+	refreshed, err := json.Marshal(fields)
+	if err != nil {
+		return prior, err
+	}
+	return jsontypes.NewNormalizedValue(string(refreshed)), nil
+}
+
+// tensorFieldsForIndex returns the tensorFields to send with documents. Structured indexes take
+// their tensor fields from the index settings, and the other index types require the list.
+func tensorFieldsForIndex(index *go_marqo.IndexDetail, tensorFields []string) []string {
+	if index.Type == "structured" {
+		return nil
+	}
+	if tensorFields == nil {
+		return []string{}
+	}
+	return tensorFields
+}
+
+// addDocumentRequest builds the request that adds the document described by model.
+func addDocumentRequest(model DocumentResourceModel, index *go_marqo.IndexDetail, modelAuth *go_marqo.ModelAuth) (go_marqo.AddDocumentsRequest, error) {
+	document, err := parseJSONObject("document_json", model.DocumentJSON.ValueString())
+	if err != nil {
+		return go_marqo.AddDocumentsRequest{}, err
+	}
+	document["_id"] = model.DocumentID.ValueString()
+
+	request := go_marqo.AddDocumentsRequest{
+		Documents:    []map[string]interface{}{document},
+		TensorFields: tensorFieldsForIndex(index, model.TensorFields),
+		ModelAuth:    modelAuth,
+	}
+	if !model.MappingsJSON.IsNull() {
+		mappings, err := parseJSONObject("mappings_json", model.MappingsJSON.ValueString())
+		if err != nil {
+			return go_marqo.AddDocumentsRequest{}, err
+		}
+		request.Mappings = mappings
+	}
+	return request, nil
+}
+
+// documentPartialUpdate returns the fields that changed between the prior and planned document,
+// with the _id, when the change can be made with a partial update. It returns nil when the
+// document must be added again: when fields are removed, when tensor fields or mappings change
+// or are affected, or when the index type does not support partial updates.
+func documentPartialUpdate(prior DocumentResourceModel, planned DocumentResourceModel, index *go_marqo.IndexDetail) map[string]interface{} {
+	if index.Type != "structured" && index.Type != indexTypeSemiStructured {
+		return nil
+	}
+	if !reflect.DeepEqual(prior.TensorFields, planned.TensorFields) ||
+		!jsonValuesEqual(prior.MappingsJSON, planned.MappingsJSON) {
+		return nil
+	}
+
+	priorFields, err := parseJSONObject("document_json", prior.DocumentJSON.ValueString())
+	if err != nil {
+		return nil
+	}
+	plannedFields, err := parseJSONObject("document_json", planned.DocumentJSON.ValueString())
+	if err != nil {
+		return nil
+	}
+	priorFields = documentFields(priorFields)
+	plannedFields = documentFields(plannedFields)
+
+	// Fields that feed vectors cannot be partially updated
+	vectorised := make(map[string]bool)
+	for _, field := range planned.TensorFields {
+		vectorised[field] = true
+	}
+	for _, field := range index.TensorFields {
+		vectorised[field] = true
+	}
+	if !planned.MappingsJSON.IsNull() {
+		mappings, err := parseJSONObject("mappings_json", planned.MappingsJSON.ValueString())
+		if err != nil {
+			return nil
+		}
+		for field, mapping := range mappings {
+			vectorised[field] = true
+			if mappingObject, ok := mapping.(map[string]interface{}); ok {
+				if weights, ok := mappingObject["weights"].(map[string]interface{}); ok {
+					for dependent := range weights {
+						vectorised[dependent] = true
+					}
+				}
+			}
+		}
+	}
+
+	for key := range priorFields {
+		if _, exists := plannedFields[key]; !exists {
+			return nil
+		}
+	}
+
+	update := map[string]interface{}{"_id": planned.DocumentID.ValueString()}
+	for key, value := range plannedFields {
+		if priorValue, exists := priorFields[key]; exists && jsonEqual(priorValue, value) {
+			continue
+		}
+		if vectorised[key] {
+			return nil
+		}
+		update[key] = value
+	}
+	return update
+}
+
+// documentUnchanged reports whether the planned document only differs from the prior one in the
+// formatting of document_json and mappings_json, so that there is nothing to send to Marqo.
+func documentUnchanged(prior DocumentResourceModel, planned DocumentResourceModel) bool {
+	if !reflect.DeepEqual(prior.TensorFields, planned.TensorFields) ||
+		!jsonValuesEqual(prior.MappingsJSON, planned.MappingsJSON) {
+		return false
+	}
+
+	priorFields, err := parseJSONObject("document_json", prior.DocumentJSON.ValueString())
+	if err != nil {
+		return false
+	}
+	plannedFields, err := parseJSONObject("document_json", planned.DocumentJSON.ValueString())
+	if err != nil {
+		return false
+	}
+	return jsonEqual(documentFields(priorFields), documentFields(plannedFields))
+}
+
+// changedFieldNames returns the sorted names of the fields in a partial update.
+func changedFieldNames(update map[string]interface{}) []string {
+	var names []string
+	for key := range update {
+		if key != "_id" {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupDocumentIndex returns the index a document belongs to, adding an error if it cannot be found.
+func (r *documentResource) lookupDocumentIndex(indexName string, diagnostics *diag.Diagnostics) *go_marqo.IndexDetail {
+	index, err := lookupIndex(r.marqoClient, indexName)
+	if err != nil {
+		diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return nil
+	}
+	if index == nil {
+		diagnostics.AddError("Index Not Found", fmt.Sprintf("Index %s does not exist", indexName))
+		return nil
+	}
+	return index
+}
+
+func (r *documentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = maskModelAuth(ctx, r.modelAuth)
+	var model DocumentResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexName := model.IndexName.ValueString()
+	index := r.lookupDocumentIndex(indexName, &resp.Diagnostics)
+	if index == nil {
+		return
+	}
+
+	request, err := addDocumentRequest(model, index, r.modelAuth)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Document", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Adding document %s to index %s", model.DocumentID.ValueString(), indexName))
+	if _, err := r.marqoClient.AddDocuments(index.MarqoEndpoint, indexName, request); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Add Document",
+			fmt.Sprintf("Could not add document %s to index %s: %s", model.DocumentID.ValueString(), indexName, err))
+		return
+	}
+
+	model.MarqoEndpoint = types.StringValue(index.MarqoEndpoint)
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DocumentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexName := state.IndexName.ValueString()
+	index, err := lookupIndex(r.marqoClient, indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return
+	}
+	if index == nil {
+		resp.Diagnostics.AddWarning(
+			"Resource Not Found",
+			fmt.Sprintf("Index %s no longer exists, so document %s will be removed from state.", indexName, state.DocumentID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	fetched, err := r.marqoClient.GetDocument(index.MarqoEndpoint, indexName, state.DocumentID.ValueString())
+	if errors.Is(err, go_marqo.ErrDocumentNotFound) {
+		resp.Diagnostics.AddWarning(
+			"Resource Not Found",
+			fmt.Sprintf("Document %s no longer exists in index %s. The state will be deleted.", state.DocumentID.ValueString(), indexName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Get Document",
+			fmt.Sprintf("Could not get document %s from index %s: %s", state.DocumentID.ValueString(), indexName, err))
+		return
+	}
+
+	state.DocumentJSON, err = refreshDocumentJSON(state.DocumentJSON, fetched)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Encode Document", err.Error())
+		return
+	}
+	state.MarqoEndpoint = types.StringValue(index.MarqoEndpoint)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = maskModelAuth(ctx, r.modelAuth)
+	var model DocumentResourceModel
+	var state DocumentResourceModel
+
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexName := model.IndexName.ValueString()
+	documentID := model.DocumentID.ValueString()
+	index := r.lookupDocumentIndex(indexName, &resp.Diagnostics)
+	if index == nil {
+		return
+	}
+
+	if documentUnchanged(state, model) {
+		tflog.Debug(ctx, fmt.Sprintf("Document %s in index %s only changed in formatting, nothing to update", documentID, indexName))
+	} else if update := documentPartialUpdate(state, model, index); update != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Partially updating document %s in index %s, fields: %s",
+			documentID, indexName, strings.Join(changedFieldNames(update), ", ")))
+		if _, err := r.marqoClient.UpdateDocuments(index.MarqoEndpoint, indexName, []map[string]interface{}{update}); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Update Document",
+				fmt.Sprintf("Could not update document %s in index %s: %s", documentID, indexName, err))
+			return
+		}
+	} else {
+		request, err := addDocumentRequest(model, index, r.modelAuth)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Document", err.Error())
+			return
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Adding document %s to index %s again", documentID, indexName))
+		if _, err := r.marqoClient.AddDocuments(index.MarqoEndpoint, indexName, request); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Update Document",
+				fmt.Sprintf("Could not add document %s to index %s: %s", documentID, indexName, err))
+			return
+		}
+	}
+
+	model.MarqoEndpoint = types.StringValue(index.MarqoEndpoint)
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *documentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DocumentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexName := state.IndexName.ValueString()
+	index, err := lookupIndex(r.marqoClient, indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return
+	}
+	// Documents are deleted with their index
+	if index == nil {
+		tflog.Info(ctx, fmt.Sprintf("Index %s no longer exists, nothing to delete", indexName))
+		return
+	}
+
+	if _, err := r.marqoClient.DeleteDocuments(index.MarqoEndpoint, indexName, []string{state.DocumentID.ValueString()}); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Delete Document",
+			fmt.Sprintf("Could not delete document %s from index %s: %s", state.DocumentID.ValueString(), indexName, err))
+	}
+}
+
+// ImportState imports a document by an ID of the form index_name/document_id. tensor_fields and
+// mappings_json are not stored by Marqo, so they are null after import.
+func (r *documentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	indexName, documentID, found := strings.Cut(req.ID, "/")
+	if !found || indexName == "" || documentID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form index_name/document_id, got %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("index_name"), indexName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("document_id"), documentID)...)
+}
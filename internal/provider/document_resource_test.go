@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceDocument(t *testing.T) {
+	t.Parallel() // Enable parallel testing
+	document_index_name := fmt.Sprintf("donotdelete_doc_%s", randomString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Check if index exists and delete if it does
+			{
+				Config: testAccEmptyConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIndexExistsAndDelete(document_index_name),
+				),
+			},
+			// Create the index and document
+			{
+				Config: testAccResourceDocumentConfig(document_index_name, "Yes"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("marqo_document.test", "document_id", "faq-1"),
+					resource.TestCheckResourceAttrSet("marqo_document.test", "marqo_endpoint"),
+				),
+			},
+			// Changing a tensor field adds the document again
+			{
+				Config: testAccResourceDocumentConfig(document_index_name, "No"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("marqo_document.test", "document_json", `{"answer":"No","question":"Can I use Terraform?"}`),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:                         "marqo_document.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        document_index_name + "/faq-1",
+				ImportStateVerifyIdentifierAttribute: "document_id",
+				ImportStateVerifyIgnore:              []string{"document_json", "tensor_fields"},
+			},
+		},
+	})
+}
+
+func testAccResourceDocumentConfig(name string, answer string) string {
+	return fmt.Sprintf(`
+		resource "marqo_index" "test" {
+			index_name = "%s"
+			settings = {
+				type = "unstructured"
+				model = "hf/e5-base-v2"
+				inference_type = "marqo.CPU.large"
+				number_of_inferences = 1
+				number_of_replicas = 0
+				number_of_shards = 1
+				storage_class = "marqo.basic"
+			}
+		}
+
+		resource "marqo_document" "test" {
+			index_name    = marqo_index.test.index_name
+			document_id   = "faq-1"
+			document_json = jsonencode({ question = "Can I use Terraform?", answer = "%s" })
+			tensor_fields = ["question", "answer"]
+		}
+	`, name, answer)
+}
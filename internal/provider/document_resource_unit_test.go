@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testDocumentModel(documentJSON string) DocumentResourceModel {
+	return DocumentResourceModel{
+		IndexName:     types.StringValue("test-index"),
+		DocumentID:    types.StringValue("faq-1"),
+		DocumentJSON:  jsontypes.NewNormalizedValue(documentJSON),
+		TensorFields:  []string{"answer"},
+		MappingsJSON:  jsontypes.NewNormalizedNull(),
+		MarqoEndpoint: types.StringValue("https://test-index.marqo.ai"),
+	}
+}
+
+func TestRefreshDocumentJSON(t *testing.T) {
+	prior := jsontypes.NewNormalizedValue(`{"answer": "Yes", "rank": 1}`)
+
+	refreshed, err := refreshDocumentJSON(prior, map[string]interface{}{"_id": "faq-1", "answer": "Yes", "rank": json.Number("1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshed.Equal(prior) {
+		t.Errorf("expected prior value to be kept, got %s", refreshed)
+	}
+
+	refreshed, err = refreshDocumentJSON(prior, map[string]interface{}{"_id": "faq-1", "answer": "No", "rank": json.Number("1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !jsonSemanticallyEqual(refreshed.ValueString(), `{"answer": "No", "rank": 1}`) {
+		t.Errorf("expected changed document, got %s", refreshed)
+	}
+}
+
+func TestDocumentLargeIntegers(t *testing.T) {
+	prior := testDocumentModel(`{"answer": "Yes", "views": 9007199254740993}`)
+
+	request, err := addDocumentRequest(prior, &go_marqo.IndexDetail{Type: "unstructured"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(request.Documents[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(encoded), `"views":9007199254740993`) {
+		t.Errorf("expected the integer to be sent unchanged, got %s", encoded)
+	}
+
+	refreshed, err := refreshDocumentJSON(prior.DocumentJSON, map[string]interface{}{"_id": "faq-1", "answer": "Yes", "views": json.Number("9007199254740992")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.Equal(prior.DocumentJSON) {
+		t.Errorf("expected a changed integer to be refreshed, got %s", refreshed)
+	}
+
+	planned := testDocumentModel(`{"answer": "Yes", "views": 9007199254740992}`)
+	if documentUnchanged(prior, planned) {
+		t.Errorf("expected a changed integer to be sent to Marqo")
+	}
+	update := documentPartialUpdate(prior, planned, &go_marqo.IndexDetail{Type: "structured", TensorFields: []string{"answer"}})
+	if !reflect.DeepEqual(update, map[string]interface{}{"_id": "faq-1", "views": json.Number("9007199254740992")}) {
+		t.Errorf("expected a partial update of views, got %v", update)
+	}
+	if !documentUnchanged(prior, testDocumentModel(`{"views": 9007199254740993.0, "answer": "Yes"}`)) {
+		t.Errorf("expected a reformatted integer to be unchanged")
+	}
+}
+
+func TestDocumentPartialUpdate(t *testing.T) {
+	structured := &go_marqo.IndexDetail{Type: "structured", TensorFields: []string{"answer"}}
+	unstructured := &go_marqo.IndexDetail{Type: "unstructured"}
+
+	tests := []struct {
+		name     string
+		index    *go_marqo.IndexDetail
+		prior    DocumentResourceModel
+		planned  DocumentResourceModel
+		expected map[string]interface{}
+	}{
+		{
+			name:     "non-tensor field changed",
+			index:    structured,
+			prior:    testDocumentModel(`{"answer": "Yes", "rank": 1}`),
+			planned:  testDocumentModel(`{"answer": "Yes", "rank": 2}`),
+			expected: map[string]interface{}{"_id": "faq-1", "rank": json.Number("2")},
+		},
+		{
+			name:    "tensor field changed",
+			index:   structured,
+			prior:   testDocumentModel(`{"answer": "Yes", "rank": 1}`),
+			planned: testDocumentModel(`{"answer": "No", "rank": 1}`),
+		},
+		{
+			name:    "field removed",
+			index:   structured,
+			prior:   testDocumentModel(`{"answer": "Yes", "rank": 1}`),
+			planned: testDocumentModel(`{"answer": "Yes"}`),
+		},
+		{
+			name:    "unstructured index",
+			index:   unstructured,
+			prior:   testDocumentModel(`{"answer": "Yes", "rank": 1}`),
+			planned: testDocumentModel(`{"answer": "Yes", "rank": 2}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := documentPartialUpdate(tt.prior, tt.planned, tt.index)
+			if !reflect.DeepEqual(update, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, update)
+			}
+		})
+	}
+}
+
+func TestDocumentUnchanged(t *testing.T) {
+	prior := testDocumentModel(`{"answer": "Yes", "rank": 1}`)
+	prior.MappingsJSON = jsontypes.NewNormalizedValue(`{"combo": {"type": "multimodal_combination", "weights": {"answer": 1}}}`)
+
+	reformatted := testDocumentModel("{\n  \"rank\": 1,\n  \"answer\": \"Yes\"\n}")
+	reformatted.MappingsJSON = jsontypes.NewNormalizedValue(`{"combo":{"weights":{"answer":1},"type":"multimodal_combination"}}`)
+	if !documentUnchanged(prior, reformatted) {
+		t.Errorf("expected a reformatted document to be unchanged")
+	}
+
+	changed := reformatted
+	changed.DocumentJSON = jsontypes.NewNormalizedValue(`{"answer": "Yes", "rank": 2}`)
+	if documentUnchanged(prior, changed) {
+		t.Errorf("expected a changed field to be detected")
+	}
+
+	changed = reformatted
+	changed.MappingsJSON = jsontypes.NewNormalizedNull()
+	if documentUnchanged(prior, changed) {
+		t.Errorf("expected removed mappings to be detected")
+	}
+}
+
+func TestPlanReformattedDocument(t *testing.T) {
+	ctx := context.Background()
+	marqo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/indexes" {
+			t.Errorf("expected the document not to be changed, got %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [{"indexName": "test-index", "type": "unstructured", "marqoEndpoint": "` + "http://" + r.Host + `"}]}`))
+	}))
+	t.Cleanup(marqo.Close)
+	server := testProtocolServer(t, marqo.URL)
+
+	schemaResp := &resource.SchemaResponse{}
+	(&documentResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	newState := func(model DocumentResourceModel) tfsdk.State {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := state.Set(ctx, &model); diags.HasError() {
+			t.Fatalf("failed to build state: %v", diags)
+		}
+		return state
+	}
+
+	model := testDocumentModel(`{"answer": "Yes", "rank": 1}`)
+	model.MarqoEndpoint = types.StringValue(marqo.URL)
+	model.MappingsJSON = jsontypes.NewNormalizedValue(`{"combo": {"type": "multimodal_combination", "weights": {"answer": 1}}}`)
+	prior := newState(model)
+
+	// Terraform proposes the configured values, keeping the prior values of computed attributes
+	reformatted := "{\n  \"rank\": 1,\n  \"answer\": \"Yes\"\n}\n"
+	model.DocumentJSON = jsontypes.NewNormalizedValue(reformatted)
+	model.MappingsJSON = jsontypes.NewNormalizedValue(`{"combo":{"weights":{"answer":1},"type":"multimodal_combination"}}`)
+	proposed := newState(model)
+	model.MarqoEndpoint = types.StringNull()
+	config := newState(model)
+
+	planResp, planned := testPlanResourceChange(t, server, "marqo_document", prior, config, proposed, nil)
+	for _, d := range planResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	if len(planResp.RequiresReplace) > 0 {
+		t.Fatalf("expected a reformatted document not to be replaced, got %v", planResp.RequiresReplace)
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "marqo_document",
+		PriorState:     testDynamicValue(t, prior.Raw),
+		PlannedState:   testDynamicValue(t, planned),
+		Config:         testDynamicValue(t, config.Raw),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil || len(applyResp.Diagnostics) > 0 {
+		t.Fatalf("failed to apply: %v %v", err, applyResp.Diagnostics)
+	}
+
+	applied, err := applyResp.NewState.Unmarshal(prior.Raw.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var documentJSON jsontypes.Normalized
+	state := tfsdk.State{Schema: prior.Schema, Raw: applied}
+	state.GetAttribute(ctx, path.Root("document_json"), &documentJSON)
+	if documentJSON.ValueString() != reformatted {
+		t.Errorf("expected the configured formatting to be kept, got %s", documentJSON)
+	}
+}
+
+func TestDocumentResourceCreate(t *testing.T) {
+	ctx := context.Background()
+
+	var added go_marqo.AddDocumentsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/indexes":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"results": [{"indexName": "test-index", "type": "unstructured", "marqoEndpoint": "` + "http://" + r.Host + `"}]}`))
+		case "/indexes/test-index/documents":
+			if err := json.NewDecoder(r.Body).Decode(&added); err != nil {
+				t.Fatal(err)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"errors": false, "items": [{"_id": "faq-1", "status": 200}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &documentResource{marqoClient: &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	model := testDocumentModel(`{"answer": "Yes"}`)
+	model.MarqoEndpoint = types.StringUnknown()
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw.Copy()}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if len(added.Documents) != 1 || added.Documents[0]["_id"] != "faq-1" || added.Documents[0]["answer"] != "Yes" {
+		t.Errorf("unexpected documents added: %v", added.Documents)
+	}
+	if !reflect.DeepEqual(added.TensorFields, []string{"answer"}) {
+		t.Errorf("unexpected tensor fields: %v", added.TensorFields)
+	}
+
+	var state DocumentResourceModel
+	resp.State.Get(ctx, &state)
+	if state.MarqoEndpoint.ValueString() != server.URL {
+		t.Errorf("expected endpoint %s, got %s", server.URL, state.MarqoEndpoint)
+	}
+}
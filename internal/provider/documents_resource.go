@@ -0,0 +1,615 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"marqo/go_marqo"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &documentsResource{}
+	_ resource.ResourceWithConfigure      = &documentsResource{}
+	_ resource.ResourceWithModifyPlan     = &documentsResource{}
+	_ resource.ResourceWithValidateConfig = &documentsResource{}
+)
+
+const (
+	// defaultDocumentsBatchSize is the number of documents sent in each request.
+	defaultDocumentsBatchSize = 50
+	// maxDocumentsBatchSize is the largest number of documents Marqo accepts in one request.
+	maxDocumentsBatchSize = 128
+	// defaultDocumentsParallelism is the number of batches sent at the same time.
+	defaultDocumentsParallelism = 4
+)
+
+// ManageDocumentsResource is a helper function to simplify the provider implementation.
+func ManageDocumentsResource() resource.Resource {
+	return &documentsResource{}
+}
+
+// documentsResource is the resource implementation.
+type documentsResource struct {
+	marqoClient *go_marqo.Client
+	modelAuth   *go_marqo.ModelAuth
+}
+
+// DocumentsResourceModel maps the resource schema data.
+type DocumentsResourceModel struct {
+	IndexName      types.String         `tfsdk:"index_name"`
+	SourceFile     types.String         `tfsdk:"source_file"`
+	TensorFields   []string             `tfsdk:"tensor_fields"`
+	MappingsJSON   jsontypes.Normalized `tfsdk:"mappings_json"`
+	BatchSize      types.Int64          `tfsdk:"batch_size"`
+	Parallelism    types.Int64          `tfsdk:"parallelism"`
+	DocumentHashes types.Map            `tfsdk:"document_hashes"`
+	DocumentCount  types.Int64          `tfsdk:"document_count"`
+	MarqoEndpoint  types.String         `tfsdk:"marqo_endpoint"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *documentsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*marqoResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *marqoResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.marqoClient = data.client
+	r.modelAuth = data.modelAuth
+}
+
+// Metadata returns the resource type name.
+func (r *documentsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_documents"
+}
+
+func (r *documentsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Keeps the documents of a Marqo index in sync with a local JSONL or JSON file. Only new and changed " +
+			"documents are added on apply, and documents removed from the file are deleted from the index. If the index " +
+			"is created again or emptied outside of Terraform, the next apply adds every document again.",
+		Attributes: map[string]schema.Attribute{
+			"index_name": schema.StringAttribute{
+				Required: true,
+				Description: "The name of the index. Reference marqo_index.<name>.index_name so that the index is " +
+					"READY before documents are added.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"source_file": schema.StringAttribute{
+				Required: true,
+				Description: "The path of a file with one JSON document per line, or a JSON array of documents. " +
+					"Every document must have a unique string _id.",
+			},
+			"tensor_fields": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The fields to vectorise, for indexes that are not structured. Changing it adds every document again.",
+			},
+			"mappings_json": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  jsontypes.NormalizedType{},
+				Description: "Marqo field mappings as a JSON object. Changing it adds every document again; formatting changes are ignored.",
+			},
+			"batch_size": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(defaultDocumentsBatchSize),
+				Description: fmt.Sprintf("The number of documents sent in each request, at most %d. Default is %d.",
+					maxDocumentsBatchSize, defaultDocumentsBatchSize),
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultDocumentsParallelism),
+				Description: fmt.Sprintf("The number of batches sent at the same time. Default is %d.", defaultDocumentsParallelism),
+			},
+			"document_hashes": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The SHA-256 hash of every document in the index, keyed by _id.",
+			},
+			"document_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of documents in the source file.",
+			},
+			"marqo_endpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Marqo endpoint of the index that serves the documents.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the batch size, parallelism and mappings_json.
+func (r *documentsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DocumentsResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.BatchSize.IsNull() && !config.BatchSize.IsUnknown() &&
+		(config.BatchSize.ValueInt64() < 1 || config.BatchSize.ValueInt64() > maxDocumentsBatchSize) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("batch_size"),
+			"Invalid Batch Size",
+			fmt.Sprintf("batch_size must be between 1 and %d, got %d.", maxDocumentsBatchSize, config.BatchSize.ValueInt64()))
+	}
+	if !config.Parallelism.IsNull() && !config.Parallelism.IsUnknown() && config.Parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Invalid Parallelism",
+			fmt.Sprintf("parallelism must be at least 1, got %d.", config.Parallelism.ValueInt64()))
+	}
+	if !config.MappingsJSON.IsNull() && !config.MappingsJSON.IsUnknown() {
+		if _, err := parseJSONObject("mappings_json", config.MappingsJSON.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("mappings_json"), "Invalid Mappings JSON", err.Error())
+		}
+	}
+}
+
+// sourceDocument is a document read from the source file.
+type sourceDocument struct {
+	id       string
+	hash     string
+	document map[string]interface{}
+}
+
+// readSourceDocuments reads the documents of a JSONL file, or of a file holding a JSON array.
+// Each document must be a JSON object with a unique string _id.
+func readSourceDocuments(filename string) ([]sourceDocument, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", filename, err)
+	}
+
+	var raw []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("%s is not a JSON array of documents: %w", filename, err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+		line := 0
+		for scanner.Scan() {
+			line++
+			if text := bytes.TrimSpace(scanner.Bytes()); len(text) > 0 {
+				if !json.Valid(text) {
+					return nil, fmt.Errorf("%s line %d is not valid JSON", filename, line)
+				}
+				raw = append(raw, json.RawMessage(append([]byte(nil), text...)))
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", filename, err)
+		}
+	}
+
+	documents := make([]sourceDocument, 0, len(raw))
+	seen := make(map[string]int, len(raw))
+	for i, message := range raw {
+		var document map[string]interface{}
+		if err := decodeJSON(message, &document); err != nil || document == nil {
+			return nil, fmt.Errorf("document %d in %s is not a JSON object", i+1, filename)
+		}
+		id, ok := document["_id"].(string)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("document %d in %s does not have a string _id", i+1, filename)
+		}
+		if previous, exists := seen[id]; exists {
+			return nil, fmt.Errorf("documents %d and %d in %s have the same _id %q", previous, i+1, filename, id)
+		}
+		seen[id] = i + 1
+
+		// encoding/json sorts map keys, so the hash does not depend on the field order in the file
+		canonical, err := json.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode document %q: %w", id, err)
+		}
+		sum := sha256.Sum256(canonical)
+		documents = append(documents, sourceDocument{id: id, hash: hex.EncodeToString(sum[:]), document: document})
+	}
+	return documents, nil
+}
+
+// sourceHashes returns the hashes of the source documents, keyed by _id.
+func sourceHashes(documents []sourceDocument) map[string]string {
+	hashes := make(map[string]string, len(documents))
+	for _, document := range documents {
+		hashes[document.id] = document.hash
+	}
+	return hashes
+}
+
+// documentHashes returns the hashes recorded in document_hashes, keyed by _id.
+func documentHashes(value types.Map) map[string]string {
+	hashes := make(map[string]string, len(value.Elements()))
+	for id, hash := range value.Elements() {
+		if hashValue, ok := hash.(types.String); ok {
+			hashes[id] = hashValue.ValueString()
+		}
+	}
+	return hashes
+}
+
+// documentChanges returns the source documents that are new or changed since prior was recorded,
+// and the sorted IDs of the recorded documents that are no longer in the source.
+func documentChanges(documents []sourceDocument, prior map[string]string) ([]sourceDocument, []string) {
+	var changed []sourceDocument
+	current := make(map[string]bool, len(documents))
+	for _, document := range documents {
+		current[document.id] = true
+		if prior[document.id] != document.hash {
+			changed = append(changed, document)
+		}
+	}
+
+	var removed []string
+	for id := range prior {
+		if !current[id] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	return changed, removed
+}
+
+// documentBatchResult is the outcome of sending one batch of documents.
+type documentBatchResult struct {
+	ids      []string
+	response go_marqo.DocumentsResponse
+	err      error
+}
+
+// failedDocuments returns the error for each document of the batch that failed, keyed by _id.
+// When the request itself failed, every document in the batch failed with its error.
+func (b documentBatchResult) failedDocuments() map[string]string {
+	failed := make(map[string]string)
+	if b.err != nil && len(b.response.Items) == 0 {
+		for _, id := range b.ids {
+			failed[id] = b.err.Error()
+		}
+		return failed
+	}
+	for _, item := range b.response.Items {
+		if item.Error != "" || item.Status >= 400 {
+			failed[item.ID] = fmt.Sprintf("status %d: %s", item.Status, item.Error)
+		}
+	}
+	return failed
+}
+
+// runDocumentBatches splits ids into batches of batchSize and calls send for each batch,
+// running up to parallelism batches at a time. Results are returned in batch order.
+func runDocumentBatches(ids []string, batchSize int, parallelism int, send func(batch []string) (go_marqo.DocumentsResponse, error)) []documentBatchResult {
+	var batches [][]string
+	for start := 0; start < len(ids); start += batchSize {
+		batches = append(batches, ids[start:min(start+batchSize, len(ids))])
+	}
+
+	results := make([]documentBatchResult, len(batches))
+	runBounded(len(batches), parallelism, func(i int) {
+		response, err := send(batches[i])
+		results[i] = documentBatchResult{ids: batches[i], response: response, err: err}
+	})
+	return results
+}
+
+// syncDocuments adds the new and changed documents and deletes the removed ones. It returns
+// the hashes of the documents now in the index: failed additions keep their prior hash, or are
+// left out if they are new, and failed deletions stay recorded, so the next plan retries them.
+// Each failed document is reported as a separate diagnostic.
+func (r *documentsResource) syncDocuments(ctx context.Context, model DocumentsResourceModel, index *go_marqo.IndexDetail,
+	documents []sourceDocument, prior map[string]string, diagnostics *diag.Diagnostics) map[string]string {
+	indexName := model.IndexName.ValueString()
+	changed, removed := documentChanges(documents, prior)
+	tflog.Info(ctx, fmt.Sprintf("Syncing documents of index %s: %d to add, %d to delete", indexName, len(changed), len(removed)))
+
+	var mappings map[string]interface{}
+	if !model.MappingsJSON.IsNull() {
+		var err error
+		if mappings, err = parseJSONObject("mappings_json", model.MappingsJSON.ValueString()); err != nil {
+			diagnostics.AddAttributeError(path.Root("mappings_json"), "Invalid Mappings JSON", err.Error())
+			return prior
+		}
+	}
+
+	byID := make(map[string]sourceDocument, len(changed))
+	changedIDs := make([]string, 0, len(changed))
+	for _, document := range changed {
+		byID[document.id] = document
+		changedIDs = append(changedIDs, document.id)
+	}
+
+	batchSize := int(model.BatchSize.ValueInt64())
+	parallelism := int(model.Parallelism.ValueInt64())
+	tensorFields := tensorFieldsForIndex(index, model.TensorFields)
+
+	hashes := make(map[string]string, len(documents))
+	for id, hash := range prior {
+		hashes[id] = hash
+	}
+
+	addResults := runDocumentBatches(changedIDs, batchSize, parallelism, func(batch []string) (go_marqo.DocumentsResponse, error) {
+		request := go_marqo.AddDocumentsRequest{TensorFields: tensorFields, Mappings: mappings, ModelAuth: r.modelAuth}
+		for _, id := range batch {
+			request.Documents = append(request.Documents, byID[id].document)
+		}
+		return r.marqoClient.AddDocuments(index.MarqoEndpoint, indexName, request)
+	})
+	for _, result := range addResults {
+		failed := result.failedDocuments()
+		for _, id := range result.ids {
+			if reason, isFailed := failed[id]; isFailed {
+				diagnostics.AddError(
+					"Failed to Add Document",
+					fmt.Sprintf("Could not add document %s to index %s: %s", id, indexName, reason))
+				continue
+			}
+			hashes[id] = byID[id].hash
+		}
+	}
+
+	deleteResults := runDocumentBatches(removed, batchSize, parallelism, func(batch []string) (go_marqo.DocumentsResponse, error) {
+		return r.marqoClient.DeleteDocuments(index.MarqoEndpoint, indexName, batch)
+	})
+	for _, result := range deleteResults {
+		failed := result.failedDocuments()
+		for _, id := range result.ids {
+			if reason, isFailed := failed[id]; isFailed {
+				diagnostics.AddError(
+					"Failed to Delete Document",
+					fmt.Sprintf("Could not delete document %s from index %s: %s", id, indexName, reason))
+				continue
+			}
+			delete(hashes, id)
+		}
+	}
+
+	return hashes
+}
+
+// hashesValue returns the document_hashes value for hashes keyed by _id.
+func hashesValue(hashes map[string]string) types.Map {
+	values := make(map[string]attr.Value, len(hashes))
+	for id, hash := range hashes {
+		values[id] = types.StringValue(hash)
+	}
+	return types.MapValueMust(types.StringType, values)
+}
+
+// ModifyPlan reads the source file so that the plan shows which documents are added,
+// changed or deleted.
+func (r *documentsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DocumentsResourceModel
+	diags := resp.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.SourceFile.IsUnknown() {
+		return
+	}
+
+	documents, err := readSourceDocuments(plan.SourceFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_file"), "Invalid Source File", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("document_hashes"), hashesValue(sourceHashes(documents)))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("document_count"), int64(len(documents)))...)
+}
+
+// lookupDocumentsIndex returns the index the documents belong to, adding an error if it cannot be found.
+func (r *documentsResource) lookupDocumentsIndex(indexName string, diagnostics *diag.Diagnostics) *go_marqo.IndexDetail {
+	index, err := lookupIndex(r.marqoClient, indexName)
+	if err != nil {
+		diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return nil
+	}
+	if index == nil {
+		diagnostics.AddError("Index Not Found", fmt.Sprintf("Index %s does not exist", indexName))
+		return nil
+	}
+	return index
+}
+
+// apply syncs the index with the source file and records the result in state. It returns the
+// index the documents were added to, or nil if nothing was sent.
+func (r *documentsResource) apply(ctx context.Context, model DocumentsResourceModel, prior map[string]string, state *tfsdk.State, diagnostics *diag.Diagnostics) *go_marqo.IndexDetail {
+	ctx = maskModelAuth(ctx, r.modelAuth)
+	index := r.lookupDocumentsIndex(model.IndexName.ValueString(), diagnostics)
+	if index == nil {
+		return nil
+	}
+
+	documents, err := readSourceDocuments(model.SourceFile.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("source_file"), "Invalid Source File", err.Error())
+		return nil
+	}
+
+	hashes := r.syncDocuments(ctx, model, index, documents, prior, diagnostics)
+	model.DocumentHashes = hashesValue(hashes)
+	model.DocumentCount = types.Int64Value(int64(len(documents)))
+	model.MarqoEndpoint = types.StringValue(index.MarqoEndpoint)
+	diagnostics.Append(state.Set(ctx, &model)...)
+	return index
+}
+
+// indexCreatedKey is the private state key that records when the index the documents were added
+// to was created, so that Read notices an index that has been deleted and created again.
+const indexCreatedKey = "index_created"
+
+// indexCreatedValue returns the private state value recording the creation time of an index.
+func indexCreatedValue(created string) []byte {
+	value, _ := json.Marshal(created)
+	return value
+}
+
+// indexLostDocuments returns why the documents recorded in state are no longer in the index, or an
+// empty string if they are. The index has lost them if it was created again since they were added,
+// or if it is now empty.
+func (r *documentsResource) indexLostDocuments(ctx context.Context, index *go_marqo.IndexDetail, recordedCreated []byte, recorded int) string {
+	if len(recordedCreated) > 0 && index.Created != "" && !bytes.Equal(recordedCreated, indexCreatedValue(index.Created)) {
+		return fmt.Sprintf("it was created again at %s", index.Created)
+	}
+	if recorded == 0 {
+		return ""
+	}
+
+	stats, err := r.marqoClient.GetIndexStats(index.IndexName)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Could not get the stats of index %s: %s", index.IndexName, err))
+		return ""
+	}
+	if stats.NumberOfDocuments == 0 {
+		return "it has no documents"
+	}
+	return ""
+}
+
+func (r *documentsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model DocumentsResourceModel
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if index := r.apply(ctx, model, map[string]string{}, &resp.State, &resp.Diagnostics); index != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, indexCreatedKey, indexCreatedValue(index.Created))...)
+	}
+}
+
+func (r *documentsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DocumentsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexName := state.IndexName.ValueString()
+	index, err := lookupIndex(r.marqoClient, indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return
+	}
+	if index == nil {
+		resp.Diagnostics.AddWarning(
+			"Resource Not Found",
+			fmt.Sprintf("Index %s no longer exists, so its documents will be removed from state.", indexName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Clearing the recorded hashes makes the next apply add every document again
+	recordedCreated, diags := req.Private.GetKey(ctx, indexCreatedKey)
+	resp.Diagnostics.Append(diags...)
+	if reason := r.indexLostDocuments(ctx, index, recordedCreated, len(state.DocumentHashes.Elements())); reason != "" {
+		resp.Diagnostics.AddWarning(
+			"Documents Not Found",
+			fmt.Sprintf("Index %s no longer holds the documents in state because %s, so every document will be added again.", indexName, reason))
+		state.DocumentHashes = hashesValue(map[string]string{})
+	}
+
+	state.MarqoEndpoint = types.StringValue(index.MarqoEndpoint)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Private != nil && index.Created != "" {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, indexCreatedKey, indexCreatedValue(index.Created))...)
+	}
+}
+
+func (r *documentsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model DocumentsResourceModel
+	var state DocumentsResourceModel
+
+	diags := req.Plan.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Documents are vectorised with the tensor fields and mappings they were added with
+	prior := documentHashes(state.DocumentHashes)
+	if !reflect.DeepEqual(model.TensorFields, state.TensorFields) || !jsonValuesEqual(model.MappingsJSON, state.MappingsJSON) {
+		tflog.Info(ctx, "tensor_fields or mappings_json changed, adding every document again")
+		for id := range prior {
+			prior[id] = ""
+		}
+	}
+
+	if index := r.apply(ctx, model, prior, &resp.State, &resp.Diagnostics); index != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, indexCreatedKey, indexCreatedValue(index.Created))...)
+	}
+}
+
+func (r *documentsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DocumentsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexName := state.IndexName.ValueString()
+	index, err := lookupIndex(r.marqoClient, indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return
+	}
+	// Documents are deleted with their index
+	if index == nil {
+		tflog.Info(ctx, fmt.Sprintf("Index %s no longer exists, nothing to delete", indexName))
+		return
+	}
+
+	remaining := r.syncDocuments(ctx, state, index, nil, documentHashes(state.DocumentHashes), &resp.Diagnostics)
+	if len(remaining) > 0 {
+		// Keep the documents that could not be deleted, so that destroy can be retried
+		state.DocumentHashes = hashesValue(remaining)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	}
+}
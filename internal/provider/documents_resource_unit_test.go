@@ -0,0 +1,398 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func writeSourceFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestReadSourceDocuments(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedIDs   []string
+		expectedError string
+	}{
+		{
+			name:        "jsonl",
+			content:     "{\"_id\": \"a\", \"text\": \"one\"}\n\n{\"_id\": \"b\", \"text\": \"two\"}\n",
+			expectedIDs: []string{"a", "b"},
+		},
+		{
+			name:        "json array",
+			content:     `[{"_id": "a", "text": "one"}, {"_id": "b", "text": "two"}]`,
+			expectedIDs: []string{"a", "b"},
+		},
+		{
+			name:          "invalid line",
+			content:       "{\"_id\": \"a\"}\n{not json}\n",
+			expectedError: "line 2 is not valid JSON",
+		},
+		{
+			name:          "missing id",
+			content:       `{"text": "one"}`,
+			expectedError: "does not have a string _id",
+		},
+		{
+			name:          "duplicate id",
+			content:       "{\"_id\": \"a\"}\n{\"_id\": \"a\"}\n",
+			expectedError: "have the same _id \"a\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := readSourceDocuments(writeSourceFile(t, "docs.jsonl", tt.content))
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, document := range documents {
+				ids = append(ids, document.id)
+			}
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("expected ids %v, got %v", tt.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestReadSourceDocumentsHashIgnoresFieldOrder(t *testing.T) {
+	first, err := readSourceDocuments(writeSourceFile(t, "first.jsonl", `{"_id": "a", "x": 1, "y": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := readSourceDocuments(writeSourceFile(t, "second.jsonl", `{"y": 2, "_id": "a", "x": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first[0].hash != second[0].hash {
+		t.Errorf("expected equal hashes, got %s and %s", first[0].hash, second[0].hash)
+	}
+}
+
+func TestReadSourceDocumentsKeepsLargeIntegers(t *testing.T) {
+	documents, err := readSourceDocuments(writeSourceFile(t, "documents.jsonl",
+		`{"_id": "a", "count": 9007199254740993}`+"\n"+`{"_id": "b", "count": 9007199254740992}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(documents[0].document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(encoded), `"count":9007199254740993`) {
+		t.Errorf("expected the integer to be sent unchanged, got %s", encoded)
+	}
+	if documents[0].hash == hashOf(t, `{"_id": "a", "count": 9007199254740992}`) {
+		t.Errorf("expected integers beyond 2^53 to change the hash")
+	}
+}
+
+// hashOf returns the hash of the single document in content.
+func hashOf(t *testing.T, content string) string {
+	t.Helper()
+	documents, err := readSourceDocuments(writeSourceFile(t, "hash.jsonl", content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return documents[0].hash
+}
+
+func TestDocumentChanges(t *testing.T) {
+	documents := []sourceDocument{
+		{id: "same", hash: "1"},
+		{id: "changed", hash: "2"},
+		{id: "new", hash: "3"},
+	}
+	prior := map[string]string{"same": "1", "changed": "old", "removed": "4"}
+
+	changed, removed := documentChanges(documents, prior)
+
+	var changedIDs []string
+	for _, document := range changed {
+		changedIDs = append(changedIDs, document.id)
+	}
+	if !reflect.DeepEqual(changedIDs, []string{"changed", "new"}) {
+		t.Errorf("expected changed [changed new], got %v", changedIDs)
+	}
+	if !reflect.DeepEqual(removed, []string{"removed"}) {
+		t.Errorf("expected removed [removed], got %v", removed)
+	}
+}
+
+func TestRunDocumentBatches(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e"}
+
+	var mu sync.Mutex
+	var sent [][]string
+	results := runDocumentBatches(ids, 2, 3, func(batch []string) (go_marqo.DocumentsResponse, error) {
+		mu.Lock()
+		sent = append(sent, batch)
+		mu.Unlock()
+		return go_marqo.DocumentsResponse{}, nil
+	})
+
+	if len(sent) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(sent))
+	}
+	expected := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	for i, result := range results {
+		if !reflect.DeepEqual(result.ids, expected[i]) {
+			t.Errorf("expected batch %d to be %v, got %v", i, expected[i], result.ids)
+		}
+	}
+}
+
+func TestSyncDocumentsReportsFailedDocuments(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/indexes/test-index/documents":
+			var request go_marqo.AddDocumentsRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Error(err)
+			}
+			var response go_marqo.DocumentsResponse
+			for _, document := range request.Documents {
+				id, _ := document["_id"].(string)
+				item := go_marqo.DocumentResponseItem{ID: id, Status: http.StatusOK}
+				if item.ID == "bad" {
+					item.Status = http.StatusBadRequest
+					item.Error = "invalid field"
+					response.Errors = true
+				}
+				response.Items = append(response.Items, item)
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(response)
+		case "/indexes/test-index/documents/delete-batch":
+			var ids []string
+			if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			deleted = append(deleted, ids...)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"items": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := &documentsResource{marqoClient: &go_marqo.Client{APIKey: "test-api-key"}}
+	model := DocumentsResourceModel{
+		IndexName:    types.StringValue("test-index"),
+		TensorFields: []string{"text"},
+		MappingsJSON: jsontypes.NewNormalizedNull(),
+		BatchSize:    types.Int64Value(1),
+		Parallelism:  types.Int64Value(2),
+	}
+	index := &go_marqo.IndexDetail{IndexName: "test-index", Type: "unstructured", MarqoEndpoint: server.URL}
+	documents := []sourceDocument{
+		{id: "good", hash: "new-good", document: map[string]interface{}{"_id": "good"}},
+		{id: "bad", hash: "new-bad", document: map[string]interface{}{"_id": "bad"}},
+	}
+	prior := map[string]string{"bad": "old-bad", "removed": "old-removed"}
+
+	var diags diag.Diagnostics
+	hashes := r.syncDocuments(context.Background(), model, index, documents, prior, &diags)
+
+	expected := map[string]string{"good": "new-good", "bad": "old-bad"}
+	if !reflect.DeepEqual(hashes, expected) {
+		t.Errorf("expected hashes %v, got %v", expected, hashes)
+	}
+	if !reflect.DeepEqual(deleted, []string{"removed"}) {
+		t.Errorf("expected removed document to be deleted, got %v", deleted)
+	}
+	if diags.ErrorsCount() != 1 || !strings.Contains(diags.Errors()[0].Detail(), "Could not add document bad") {
+		t.Errorf("expected one error for document bad, got %v", diags)
+	}
+}
+
+func TestPlanReformattedDocumentsMappings(t *testing.T) {
+	ctx := context.Background()
+	marqo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/indexes" {
+			t.Errorf("expected no documents to be sent, got %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [{"indexName": "test-index", "type": "unstructured", "marqoEndpoint": "` + "http://" + r.Host + `"}]}`))
+	}))
+	t.Cleanup(marqo.Close)
+	server := testProtocolServer(t, marqo.URL)
+
+	filename := writeSourceFile(t, "documents.jsonl", `{"_id": "a", "text": "one"}`)
+	documents, err := readSourceDocuments(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	(&documentsResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	newState := func(model DocumentsResourceModel) tfsdk.State {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := state.Set(ctx, &model); diags.HasError() {
+			t.Fatalf("failed to build state: %v", diags)
+		}
+		return state
+	}
+
+	model := DocumentsResourceModel{
+		IndexName:      types.StringValue("test-index"),
+		SourceFile:     types.StringValue(filename),
+		TensorFields:   []string{"text"},
+		MappingsJSON:   jsontypes.NewNormalizedValue(`{"combo": {"type": "multimodal_combination", "weights": {"text": 1}}}`),
+		BatchSize:      types.Int64Value(defaultDocumentsBatchSize),
+		Parallelism:    types.Int64Value(defaultDocumentsParallelism),
+		DocumentHashes: hashesValue(sourceHashes(documents)),
+		DocumentCount:  types.Int64Value(1),
+		MarqoEndpoint:  types.StringValue(marqo.URL),
+	}
+	prior := newState(model)
+
+	// Terraform proposes the configured values, keeping the prior values of computed attributes
+	model.MappingsJSON = jsontypes.NewNormalizedValue("{\n  \"combo\": {\"weights\": {\"text\": 1}, \"type\": \"multimodal_combination\"}\n}")
+	proposed := newState(model)
+	model.BatchSize = types.Int64Null()
+	model.Parallelism = types.Int64Null()
+	model.DocumentHashes = types.MapNull(types.StringType)
+	model.DocumentCount = types.Int64Null()
+	model.MarqoEndpoint = types.StringNull()
+	config := newState(model)
+
+	planResp, planned := testPlanResourceChange(t, server, "marqo_documents", prior, config, proposed, nil)
+	for _, d := range planResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "marqo_documents",
+		PriorState:     testDynamicValue(t, prior.Raw),
+		PlannedState:   testDynamicValue(t, planned),
+		Config:         testDynamicValue(t, config.Raw),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil || len(applyResp.Diagnostics) > 0 {
+		t.Fatalf("failed to apply: %v %v", err, applyResp.Diagnostics)
+	}
+}
+
+func TestReadDocumentsOfRecreatedIndex(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name            string
+		recordedCreated string
+		documentsInStat int64
+		expectedHashes  int
+	}{
+		{name: "same index", recordedCreated: "2024-01-01T00:00:00Z", documentsInStat: 2, expectedHashes: 2},
+		{name: "index created again", recordedCreated: "2023-06-01T00:00:00Z", documentsInStat: 2, expectedHashes: 0},
+		{name: "index emptied", recordedCreated: "2024-01-01T00:00:00Z", documentsInStat: 0, expectedHashes: 0},
+		{name: "creation time not recorded", documentsInStat: 2, expectedHashes: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marqo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				switch r.URL.Path {
+				case "/indexes":
+					_, _ = w.Write([]byte(`{"results": [{"indexName": "test-index", "type": "unstructured", "Created": "2024-01-01T00:00:00Z", "marqoEndpoint": "` + "http://" + r.Host + `"}]}`))
+				case "/indexes/test-index/stats":
+					_ = json.NewEncoder(w).Encode(go_marqo.IndexStats{NumberOfDocuments: tt.documentsInStat})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			t.Cleanup(marqo.Close)
+			server := testProtocolServer(t, marqo.URL)
+
+			schemaResp := &resource.SchemaResponse{}
+			(&documentsResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			model := DocumentsResourceModel{
+				IndexName:      types.StringValue("test-index"),
+				SourceFile:     types.StringValue("documents.jsonl"),
+				MappingsJSON:   jsontypes.NewNormalizedNull(),
+				BatchSize:      types.Int64Value(defaultDocumentsBatchSize),
+				Parallelism:    types.Int64Value(defaultDocumentsParallelism),
+				DocumentHashes: hashesValue(map[string]string{"a": "hash-a", "b": "hash-b"}),
+				DocumentCount:  types.Int64Value(2),
+				MarqoEndpoint:  types.StringValue(marqo.URL),
+			}
+			if diags := state.Set(ctx, &model); diags.HasError() {
+				t.Fatalf("failed to build state: %v", diags)
+			}
+
+			var private []byte
+			if tt.recordedCreated != "" {
+				private, _ = json.Marshal(map[string][]byte{indexCreatedKey: indexCreatedValue(tt.recordedCreated)})
+			}
+			resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     "marqo_documents",
+				CurrentState: testDynamicValue(t, state.Raw),
+				Private:      private,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			newState, err := resp.NewState.Unmarshal(state.Raw.Type())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var refreshed DocumentsResourceModel
+			(&tfsdk.State{Schema: schemaResp.Schema, Raw: newState}).Get(ctx, &refreshed)
+			if got := len(refreshed.DocumentHashes.Elements()); got != tt.expectedHashes {
+				t.Errorf("expected %d recorded documents, got %d", tt.expectedHashes, got)
+			}
+
+			var recorded map[string][]byte
+			if err := json.Unmarshal(resp.Private, &recorded); err != nil {
+				t.Fatalf("failed to decode private state %q: %v", resp.Private, err)
+			}
+			if string(recorded[indexCreatedKey]) != `"2024-01-01T00:00:00Z"` {
+				t.Errorf("expected the index creation time to be recorded, got %q", recorded[indexCreatedKey])
+			}
+		})
+	}
+}
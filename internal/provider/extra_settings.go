@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// parseExtraSettings decodes extra_settings_json, which must be a JSON object.
+func parseExtraSettings(value string) (map[string]interface{}, error) {
+	return parseJSONObject("extra_settings_json", value)
+}
+
+// typedSettings maps the index settings that the typed attributes of settings send to Marqo to
+// those attributes. extra_settings_json cannot set them, since the index would then differ from
+// the typed attribute on every refresh.
+var typedSettings = map[string]string{
+	"type":                         "type",
+	"vectorNumericType":            "vector_numeric_type",
+	"numberOfInferences":           "number_of_inferences",
+	"allFields":                    "all_fields",
+	"tensorFields":                 "tensor_fields",
+	"inferenceType":                "inference_type",
+	"storageClass":                 "storage_class",
+	"numberOfShards":               "number_of_shards",
+	"numberOfReplicas":             "number_of_replicas",
+	"treatUrlsAndPointersAsImages": "treat_urls_and_pointers_as_images",
+	"treatUrlsAndPointersAsMedia":  "treat_urls_and_pointers_as_media",
+	"model":                        "model",
+	"modelProperties":              "model_properties",
+	"normalizeEmbeddings":          "normalize_embeddings",
+	"textPreprocessing":            "text_preprocessing",
+	"imagePreprocessing":           "image_preprocessing",
+	"videoPreprocessing":           "video_preprocessing",
+	"audioPreprocessing":           "audio_preprocessing",
+	"annParameters":                "ann_parameters",
+	"filterStringMaxLength":        "filter_string_max_length",
+}
+
+// typedSettingConflicts returns the keys of extra that a typed attribute already sets, in order.
+func typedSettingConflicts(extra map[string]interface{}) []string {
+	var conflicts []string
+	for key := range extra {
+		if _, typed := typedSettings[key]; typed {
+			conflicts = append(conflicts, key)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// decodeJSON decodes a single JSON value into value. Numbers are decoded as json.Number and kept
+// as written, so integers beyond 2^53 are not rounded.
+func decodeJSON(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+// jsonEqual reports whether two values decoded by decodeJSON are equal. Numbers are compared by
+// value, so 1 and 1.0 are equal while 9007199254740992 and 9007199254740993 are not.
+func jsonEqual(a interface{}, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, aValue := range a {
+			bValue, exists := b[key]
+			if !exists || !jsonEqual(aValue, bValue) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if a == b {
+			return true
+		}
+		aValue, _, aErr := big.ParseFloat(string(a), 10, 256, big.ToNearestEven)
+		bValue, _, bErr := big.ParseFloat(string(b), 10, 256, big.ToNearestEven)
+		return aErr == nil && bErr == nil && aValue.Cmp(bValue) == 0
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// parseJSONObject decodes the value of a JSON string attribute, which must be a JSON object.
+func parseJSONObject(name string, value string) (map[string]interface{}, error) {
+	var object map[string]interface{}
+	if err := decodeJSON([]byte(value), &object); err != nil {
+		return nil, fmt.Errorf("%s must be a JSON object: %w", name, err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("%s must be a JSON object, got null", name)
+	}
+	return object, nil
+}
+
+// mergeSettings deep-merges extra into settings. Nested objects are merged key by key and any
+// other value in extra replaces the one in settings. Index creation only merges keys that no typed
+// attribute sets, as ValidateConfig rejects the others.
+func mergeSettings(settings map[string]interface{}, extra map[string]interface{}) {
+	for key, value := range extra {
+		extraObject, extraIsObject := value.(map[string]interface{})
+		settingsObject, settingsIsObject := settings[key].(map[string]interface{})
+		if extraIsObject && settingsIsObject {
+			mergeSettings(settingsObject, extraObject)
+			continue
+		}
+		settings[key] = value
+	}
+}
+
+// projectSettings returns the values the index reports for the keys of extra. Keys the index
+// does not report keep their value from extra, since they cannot be compared.
+func projectSettings(extra map[string]interface{}, reported map[string]interface{}) map[string]interface{} {
+	projected := make(map[string]interface{}, len(extra))
+	for key, value := range extra {
+		reportedValue, exists := reported[key]
+		if !exists {
+			projected[key] = value
+			continue
+		}
+
+		extraObject, extraIsObject := value.(map[string]interface{})
+		reportedObject, reportedIsObject := reportedValue.(map[string]interface{})
+		if extraIsObject && reportedIsObject {
+			projected[key] = projectSettings(extraObject, reportedObject)
+			continue
+		}
+		projected[key] = reportedValue
+	}
+	return projected
+}
+
+// jsonSemanticallyEqual reports whether two JSON documents decode to the same value.
+func jsonSemanticallyEqual(a string, b string) bool {
+	var aValue, bValue interface{}
+	if err := decodeJSON([]byte(a), &aValue); err != nil {
+		return false
+	}
+	if err := decodeJSON([]byte(b), &bValue); err != nil {
+		return false
+	}
+	return jsonEqual(aValue, bValue)
+}
+
+// jsonValuesEqual reports whether two JSON string attributes are both null or hold JSON documents
+// that decode to the same value.
+func jsonValuesEqual(a jsontypes.Normalized, b jsontypes.Normalized) bool {
+	if a.IsNull() || b.IsNull() {
+		return a.IsNull() && b.IsNull()
+	}
+	return jsonSemanticallyEqual(a.ValueString(), b.ValueString())
+}
+
+// refreshExtraSettings returns extra_settings_json as round-tripped by the index. The prior
+// value is kept when it is semantically equal, so that formatting differences are not reported.
+func refreshExtraSettings(prior jsontypes.Normalized, reported map[string]interface{}) jsontypes.Normalized {
+	if prior.IsNull() || prior.IsUnknown() {
+		return prior
+	}
+
+	extra, err := parseExtraSettings(prior.ValueString())
+	if err != nil {
+		return prior
+	}
+
+	refreshed, err := json.Marshal(projectSettings(extra, reported))
+	if err != nil || jsonSemanticallyEqual(prior.ValueString(), string(refreshed)) {
+		return prior
+	}
+	return jsontypes.NewNormalizedValue(string(refreshed))
+}
+
+// jsonRequiresReplace replaces the resource when a JSON string attribute changes, unless the
+// configured value only differs from the prior one in formatting.
+func jsonRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !jsonSemanticallyEqual(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"Changing the JSON value replaces the resource; formatting differences are ignored.",
+		"Changing the JSON value replaces the resource; formatting differences are ignored.",
+	)
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"marqo/go_marqo"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestMergeSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"type":  "unstructured",
+		"model": "open_clip/ViT-L-14/laion2b_s32b_b82k",
+		"annParameters": map[string]interface{}{
+			"spaceType": "prenormalized-angular",
+			"parameters": map[string]interface{}{
+				"efConstruction": 512,
+				"m":              16,
+			},
+		},
+	}
+
+	extra, err := parseExtraSettings(`{"textChunkPrefix": "passage: ", "model": "hf/e5-base-v2", "annParameters": {"parameters": {"m": 32}}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mergeSettings(settings, extra)
+
+	expected := map[string]interface{}{
+		"type":            "unstructured",
+		"model":           "hf/e5-base-v2",
+		"textChunkPrefix": "passage: ",
+		"annParameters": map[string]interface{}{
+			"spaceType": "prenormalized-angular",
+			"parameters": map[string]interface{}{
+				"efConstruction": 512,
+				"m":              json.Number("32"),
+			},
+		},
+	}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("expected %v, got %v", expected, settings)
+	}
+
+	for _, invalid := range []string{`["a"]`, `null`, `{`} {
+		if _, err := parseExtraSettings(invalid); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestRefreshExtraSettings(t *testing.T) {
+	prior := jsontypes.NewNormalizedValue(`{
+		"textChunkPrefix": "passage: ",
+		"textQueryPrefix": "query: "
+	}`)
+
+	tests := []struct {
+		name     string
+		reported map[string]interface{}
+		expected jsontypes.Normalized
+	}{
+		{
+			name:     "round-tripped values keep the prior formatting",
+			reported: map[string]interface{}{"textChunkPrefix": "passage: ", "textQueryPrefix": "query: ", "type": "unstructured"},
+			expected: prior,
+		},
+		{
+			name:     "values the index does not report are kept",
+			reported: map[string]interface{}{"textChunkPrefix": "passage: "},
+			expected: prior,
+		},
+		{
+			name:     "changed values are refreshed",
+			reported: map[string]interface{}{"textChunkPrefix": "chunk: ", "textQueryPrefix": "query: "},
+			expected: jsontypes.NewNormalizedValue(`{"textChunkPrefix":"chunk: ","textQueryPrefix":"query: "}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refreshExtraSettings(prior, tt.reported); !got.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if got := refreshExtraSettings(jsontypes.NewNormalizedNull(), map[string]interface{}{"textChunkPrefix": "passage: "}); !got.IsNull() {
+		t.Errorf("expected null to stay null, got %s", got)
+	}
+}
+
+func TestJSONValuesEqual(t *testing.T) {
+	value := jsontypes.NewNormalizedValue(`{"a":1,"b":[1,2]}`)
+
+	tests := []struct {
+		name     string
+		other    jsontypes.Normalized
+		expected bool
+	}{
+		{name: "reformatted", other: jsontypes.NewNormalizedValue("{\n  \"b\": [1, 2],\n  \"a\": 1.0\n}"), expected: true},
+		{name: "changed", other: jsontypes.NewNormalizedValue(`{"a":2,"b":[1,2]}`), expected: false},
+		{name: "null", other: jsontypes.NewNormalizedNull(), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonValuesEqual(value, tt.other); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if !jsonValuesEqual(jsontypes.NewNormalizedNull(), jsontypes.NewNormalizedNull()) {
+		t.Errorf("expected null values to be equal")
+	}
+}
+
+func TestPlanReformattedExtraSettings(t *testing.T) {
+	ctx := context.Background()
+	live := go_marqo.IndexDetail{
+		IndexName:          "test-index",
+		IndexStatus:        "READY",
+		Type:               "unstructured",
+		Model:              "open_clip/ViT-L-14/laion2b_s32b_b82k",
+		InferenceType:      "CPU.LARGE",
+		NumberOfInferences: 1,
+		StorageClass:       "BASIC",
+		NumberOfShards:     1,
+	}
+	marqo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected the index not to be changed, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(go_marqo.IndexResponse{Results: []go_marqo.IndexDetail{live}})
+	}))
+	t.Cleanup(marqo.Close)
+	server := testProtocolServer(t, marqo.URL)
+
+	model := testIndexModel("READY")
+	model.Settings.ExtraSettingsJSON = jsontypes.NewNormalizedValue(`{"textChunkPrefix":"passage: ","annParameters":{"parameters":{"m":32}}}`)
+	configFormIndexSettings(&model.Settings)
+	prior := newTestIndexState(t, model)
+
+	// Terraform proposes the configured values, keeping the prior values of computed attributes
+	reformatted := "{\n  \"annParameters\": {\"parameters\": {\"m\": 32}},\n  \"textChunkPrefix\": \"passage: \"\n}\n"
+	model.Settings.ExtraSettingsJSON = jsontypes.NewNormalizedValue(reformatted)
+	proposed := newTestIndexState(t, model)
+	model.MarqoEndpoint = types.StringNull()
+	model.IndexStatus = types.StringNull()
+	model.DiscoveredFields = types.SetNull(discoveredFieldObjectType)
+	config := newTestIndexState(t, model)
+
+	planResp, planned := testPlanResourceChange(t, server, "marqo_index", prior, config, proposed, nil)
+	for _, d := range planResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	if len(planResp.RequiresReplace) > 0 {
+		t.Fatalf("expected a reformatted extra_settings_json not to replace the index, got %v", planResp.RequiresReplace)
+	}
+
+	// A changed value still replaces the index
+	model.Settings.ExtraSettingsJSON = jsontypes.NewNormalizedValue(`{"textChunkPrefix":"chunk: "}`)
+	changedConfig := newTestIndexState(t, model)
+	changedResp, _ := testPlanResourceChange(t, server, "marqo_index", prior, changedConfig, changedConfig, nil)
+	if len(changedResp.RequiresReplace) == 0 {
+		t.Errorf("expected a changed extra_settings_json to replace the index")
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "marqo_index",
+		PriorState:     testDynamicValue(t, prior.Raw),
+		PlannedState:   testDynamicValue(t, planned),
+		Config:         testDynamicValue(t, config.Raw),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil || len(applyResp.Diagnostics) > 0 {
+		t.Fatalf("failed to apply: %v %v", err, applyResp.Diagnostics)
+	}
+
+	newState, err := applyResp.NewState.Unmarshal(prior.Raw.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var extraSettings jsontypes.Normalized
+	state := tfsdk.State{Schema: prior.Schema, Raw: newState}
+	state.GetAttribute(ctx, path.Root("settings").AtName("extra_settings_json"), &extraSettings)
+	if extraSettings.ValueString() != reformatted {
+		t.Errorf("expected the configured formatting to be kept, got %s", extraSettings)
+	}
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &indexDataSource{}
+	_ datasource.DataSourceWithConfigure = &indexDataSource{}
+)
+
+// ReadIndexDataSource is a helper function to simplify the provider implementation.
+func ReadIndexDataSource() datasource.DataSource {
+	return &indexDataSource{}
+}
+
+// indexDataSource is the data source implementation.
+type indexDataSource struct {
+	marqoClient *go_marqo.Client
+}
+
+// IndexDataSourceModel maps the data source schema data. Settings has the type of the
+// marqo_index settings attribute.
+type IndexDataSourceModel struct {
+	IndexName     types.String       `tfsdk:"index_name"`
+	Settings      IndexSettingsModel `tfsdk:"settings"`
+	IndexStatus   types.String       `tfsdk:"index_status"`
+	MarqoEndpoint types.String       `tfsdk:"marqo_endpoint"`
+	MarqoVersion  types.String       `tfsdk:"marqo_version"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *indexDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*go_marqo.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *go_marqo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.marqoClient = client
+}
+
+// Metadata returns the data source type name.
+func (d *indexDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index"
+}
+
+// Schema defines the schema for the data source.
+func (d *indexDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single Marqo index by name.",
+		Attributes: map[string]schema.Attribute{
+			"index_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the index.",
+			},
+			"index_status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the index, e.g. CREATING, MODIFYING, READY or FAILED.",
+			},
+			"marqo_endpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Marqo endpoint used by the index.",
+			},
+			"marqo_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The version of Marqo used by the index.",
+			},
+			"settings": indexSettingsDataSourceSchema(),
+		},
+	}
+}
+
+// indexSettingsDataSourceSchema returns a computed copy of the marqo_index settings attribute,
+// so that its value has the same type as the resource's.
+func indexSettingsDataSourceSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed: true,
+		Description: "The settings of the index, in the form of the marqo_index settings attribute. Optional settings " +
+			"that Marqo leaves out are filled in with the defaults it applies. extra_settings_json is always null.",
+		Attributes: map[string]schema.Attribute{
+			"type":                 schema.StringAttribute{Computed: true},
+			"vector_numeric_type":  schema.StringAttribute{Computed: true},
+			"number_of_inferences": schema.Int64Attribute{Computed: true},
+			"all_fields": schema.SetNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{Computed: true},
+						"type": schema.StringAttribute{Computed: true},
+						"features": schema.SetAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"dependent_fields": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.Float64Type,
+						},
+					},
+				},
+			},
+			"tensor_fields": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"inference_type":                    schema.StringAttribute{Computed: true},
+			"storage_class":                     schema.StringAttribute{Computed: true},
+			"number_of_shards":                  schema.Int64Attribute{Computed: true},
+			"number_of_replicas":                schema.Int64Attribute{Computed: true},
+			"treat_urls_and_pointers_as_images": schema.BoolAttribute{Computed: true},
+			"treat_urls_and_pointers_as_media":  schema.BoolAttribute{Computed: true},
+			"model":                             schema.StringAttribute{Computed: true},
+			"model_properties": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"name":       schema.StringAttribute{Computed: true},
+					"dimensions": schema.Int64Attribute{Computed: true},
+					"type":       schema.StringAttribute{Computed: true},
+					"tokens":     schema.Int64Attribute{Computed: true},
+					"model_location": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"s3": schema.SingleNestedAttribute{
+								Computed: true,
+								Attributes: map[string]schema.Attribute{
+									"bucket": schema.StringAttribute{Computed: true},
+									"key":    schema.StringAttribute{Computed: true},
+								},
+							},
+							"hf": schema.SingleNestedAttribute{
+								Computed: true,
+								Attributes: map[string]schema.Attribute{
+									"repo_id":  schema.StringAttribute{Computed: true},
+									"filename": schema.StringAttribute{Computed: true},
+								},
+							},
+							"auth_required": schema.BoolAttribute{Computed: true},
+						},
+					},
+					"url":                schema.StringAttribute{Computed: true},
+					"trust_remote_code":  schema.BoolAttribute{Computed: true},
+					"is_marqtuned_model": schema.BoolAttribute{Computed: true},
+				},
+			},
+			"normalize_embeddings": schema.BoolAttribute{Computed: true},
+			"text_preprocessing": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"split_length":  schema.Int64Attribute{Computed: true},
+					"split_method":  schema.StringAttribute{Computed: true},
+					"split_overlap": schema.Int64Attribute{Computed: true},
+				},
+			},
+			"image_preprocessing": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"patch_method": schema.StringAttribute{Computed: true},
+				},
+			},
+			"video_preprocessing": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"split_length":  schema.Int64Attribute{Computed: true},
+					"split_overlap": schema.Int64Attribute{Computed: true},
+				},
+			},
+			"audio_preprocessing": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"split_length":  schema.Int64Attribute{Computed: true},
+					"split_overlap": schema.Int64Attribute{Computed: true},
+				},
+			},
+			"ann_parameters": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"space_type": schema.StringAttribute{Computed: true},
+					"parameters": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"ef_construction": schema.Int64Attribute{Computed: true},
+							"m":               schema.Int64Attribute{Computed: true},
+						},
+					},
+				},
+			},
+			"filter_string_max_length": schema.Int64Attribute{Computed: true},
+			"extra_settings_json":      schema.StringAttribute{Computed: true, CustomType: jsontypes.NormalizedType{}},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *indexDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var indexName string
+	diags := req.Config.GetAttribute(ctx, path.Root("index_name"), &indexName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Looking up index %s", indexName))
+	indexDetail, err := lookupIndex(d.marqoClient, indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return
+	}
+	if indexDetail == nil {
+		resp.Diagnostics.AddError("Index Not Found", fmt.Sprintf("Index %s does not exist", indexName))
+		return
+	}
+
+	model := indexModelFromDetail(*indexDetail, nil)
+	configFormIndexSettings(&model.Settings)
+	model.Settings.ExtraSettingsJSON = jsontypes.NewNormalizedNull()
+
+	state := IndexDataSourceModel{
+		IndexName:     model.IndexName,
+		Settings:      model.Settings,
+		IndexStatus:   model.IndexStatus,
+		MarqoEndpoint: model.MarqoEndpoint,
+		MarqoVersion:  types.StringValue(indexDetail.MarqoVersion),
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceIndex(t *testing.T) {
+	t.Parallel()
+	index_name := fmt.Sprintf("donotdelete_index_dsrc_%s", randomString(7))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Check if index exists and delete if it does
+			{
+				Config: testAccEmptyConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIndexExistsAndDelete(index_name),
+				),
+			},
+			// Create an index and look it up by name
+			{
+				Config: testAccDataSourceIndexConfig(index_name) + `
+					data "marqo_index" "test" {
+						index_name = marqo_index.test.index_name
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.marqo_index.test", "index_status", "READY"),
+					resource.TestCheckResourceAttrPair("data.marqo_index.test", "marqo_endpoint", "marqo_index.test", "marqo_endpoint"),
+					resource.TestCheckResourceAttrPair("data.marqo_index.test", "settings.model", "marqo_index.test", "settings.model"),
+					resource.TestCheckResourceAttrPair("data.marqo_index.test", "settings.inference_type", "marqo_index.test", "settings.inference_type"),
+					resource.TestCheckResourceAttrSet("data.marqo_index.test", "marqo_version"),
+				),
+			},
+		},
+	})
+}
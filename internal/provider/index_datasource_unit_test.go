@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIndexDataSourceSettingsMatchResource(t *testing.T) {
+	ctx := context.Background()
+
+	resourceSchema := &resource.SchemaResponse{}
+	(&indicesResource{}).Schema(ctx, resource.SchemaRequest{}, resourceSchema)
+	dataSourceSchema := &datasource.SchemaResponse{}
+	(&indexDataSource{}).Schema(ctx, datasource.SchemaRequest{}, dataSourceSchema)
+
+	resourceType, diags := resourceSchema.Schema.TypeAtPath(ctx, path.Root("settings"))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	dataSourceType, diags := dataSourceSchema.Schema.TypeAtPath(ctx, path.Root("settings"))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !resourceType.Equal(dataSourceType) {
+		t.Errorf("expected the data source settings type to match the resource:\n%s\n%s", resourceType, dataSourceType)
+	}
+}
+
+func readIndexDataSource(ctx context.Context, t *testing.T, client *go_marqo.Client, indexName string) *datasource.ReadResponse {
+	t.Helper()
+	d := &indexDataSource{marqoClient: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	// The config is built through a state, as tfsdk.Config cannot be set
+	values := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := values.SetAttribute(ctx, path.Root("index_name"), indexName); diags.HasError() {
+		t.Fatalf("failed to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: values.Raw}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw.Copy()}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func TestIndexDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": [{
+			"indexName": "other-team-index",
+			"indexStatus": "READY",
+			"marqoEndpoint": "https://other-team-index.marqo.ai",
+			"marqoVersion": "2.11.0",
+			"type": "unstructured",
+			"model": "hf/e5-base-v2",
+			"inferenceType": "CPU.LARGE",
+			"storageClass": "BASIC",
+			"numberOfInferences": 1,
+			"numberOfShards": 1,
+			"numberOfReplicas": 0
+		}]}`))
+	}))
+	defer server.Close()
+	client := &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}
+
+	resp := readIndexDataSource(ctx, t, client, "other-team-index")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state IndexDataSourceModel
+	resp.State.Get(ctx, &state)
+	expected := map[string]types.String{
+		"index_status":   types.StringValue("READY"),
+		"marqo_endpoint": types.StringValue("https://other-team-index.marqo.ai"),
+		"marqo_version":  types.StringValue("2.11.0"),
+		"inference_type": types.StringValue("marqo.CPU.large"),
+		"storage_class":  types.StringValue("marqo.basic"),
+	}
+	actual := map[string]types.String{
+		"index_status":   state.IndexStatus,
+		"marqo_endpoint": state.MarqoEndpoint,
+		"marqo_version":  state.MarqoVersion,
+		"inference_type": state.Settings.InferenceType,
+		"storage_class":  state.Settings.StorageClass,
+	}
+	for name, value := range expected {
+		if !actual[name].Equal(value) {
+			t.Errorf("expected %s to be %s, got %s", name, value, actual[name])
+		}
+	}
+	if state.Settings.NumberOfShards.ValueInt64() != 1 || state.Settings.TextPreprocessing == nil {
+		t.Errorf("unexpected settings: %+v", state.Settings)
+	}
+
+	resp = readIndexDataSource(ctx, t, client, "missing-index")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Index Not Found" {
+		t.Errorf("expected an Index Not Found error, got %v", resp.Diagnostics)
+	}
+}
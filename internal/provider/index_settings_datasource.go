@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &indexSettingsDataSource{}
+	_ datasource.DataSourceWithConfigure = &indexSettingsDataSource{}
+)
+
+// ReadIndexSettingsDataSource is a helper function to simplify the provider implementation.
+func ReadIndexSettingsDataSource() datasource.DataSource {
+	return &indexSettingsDataSource{}
+}
+
+// indexSettingsDataSource is the data source implementation.
+type indexSettingsDataSource struct {
+	marqoClient *go_marqo.Client
+}
+
+// IndexSettingsDataSourceModel maps the data source schema data.
+type IndexSettingsDataSourceModel struct {
+	IndexName    types.String       `tfsdk:"index_name"`
+	Settings     IndexSettingsModel `tfsdk:"settings"`
+	SettingsJSON types.String       `tfsdk:"settings_json"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *indexSettingsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*go_marqo.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *go_marqo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.marqoClient = client
+}
+
+// Metadata returns the data source type name.
+func (d *indexSettingsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index_settings"
+}
+
+// Schema defines the schema for the data source.
+func (d *indexSettingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the settings of a Marqo index, for example to create another index with the same settings.",
+		Attributes: map[string]schema.Attribute{
+			"index_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the index.",
+			},
+			"settings": indexSettingsDataSourceSchema(),
+			"settings_json": schema.StringAttribute{
+				Computed: true,
+				Description: "Every setting Marqo reports for the index as a JSON object with sorted keys, in the form " +
+					"of the Marqo API. It includes settings this provider does not support yet.",
+			},
+		},
+	}
+}
+
+// indexDetailFromSettings copies index settings into an IndexDetail, so that they can be
+// converted in the same way as the indexes returned by ListIndices.
+func indexDetailFromSettings(indexName string, settings go_marqo.IndexSettings) go_marqo.IndexDetail {
+	detail := go_marqo.IndexDetail{
+		IndexName:                    indexName,
+		Type:                         settings.Type,
+		VectorNumericType:            settings.VectorNumericType,
+		Model:                        settings.Model,
+		NormalizeEmbeddings:          settings.NormalizeEmbeddings,
+		TextPreprocessing:            settings.TextPreprocessing,
+		ImagePreprocessing:           settings.ImagePreprocessing,
+		VideoPreprocessing:           settings.VideoPreprocessing,
+		AudioPreprocessing:           settings.AudioPreprocessing,
+		AnnParameters:                settings.AnnParameters,
+		TensorFields:                 settings.TensorFields,
+		AllFields:                    settings.AllFields,
+		NumberOfInferences:           settings.NumberOfInferences,
+		InferenceType:                settings.InferenceType,
+		StorageClass:                 settings.StorageClass,
+		NumberOfShards:               settings.NumberOfShards,
+		NumberOfReplicas:             settings.NumberOfReplicas,
+		TreatUrlsAndPointersAsImages: settings.TreatUrlsAndPointersAsImages,
+		TreatUrlsAndPointersAsMedia:  settings.TreatUrlsAndPointersAsMedia,
+		FilterStringMaxLength:        settings.FilterStringMaxLength,
+		Raw:                          settings.Raw,
+	}
+	if settings.ModelProperties != nil {
+		detail.ModelProperties = *settings.ModelProperties
+	}
+	return detail
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *indexSettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var indexName string
+	diags := req.Config.GetAttribute(ctx, path.Root("index_name"), &indexName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting settings of index %s", indexName))
+	settings, err := d.marqoClient.GetIndexSettings(indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Get Index Settings", fmt.Sprintf("Could not get settings of index %s: %s", indexName, err))
+		return
+	}
+
+	// Maps are encoded with sorted keys
+	settingsJSON, err := json.Marshal(settings.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Encode Index Settings", err.Error())
+		return
+	}
+
+	model := indexModelFromDetail(indexDetailFromSettings(indexName, settings), nil)
+	configFormIndexSettings(&model.Settings)
+	model.Settings.ExtraSettingsJSON = jsontypes.NewNormalizedNull()
+
+	state := IndexSettingsDataSourceModel{
+		IndexName:    types.StringValue(indexName),
+		Settings:     model.Settings,
+		SettingsJSON: types.StringValue(string(settingsJSON)),
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
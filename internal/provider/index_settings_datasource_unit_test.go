@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIndexSettingsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/indexes/source-index/settings" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"type": "unstructured",
+			"model": "my-model",
+			"modelProperties": {"name": "ViT-B-32", "dimensions": 512, "type": "open_clip"},
+			"inferenceType": "GPU",
+			"storageClass": "BALANCED",
+			"numberOfInferences": 1,
+			"numberOfShards": 2,
+			"numberOfReplicas": 1,
+			"videoPreprocessing": {"splitLength": 20, "splitOverlap": 3},
+			"textChunkPrefix": "passage: "
+		}`))
+	}))
+	defer server.Close()
+
+	d := &indexSettingsDataSource{marqoClient: &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	values := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := values.SetAttribute(ctx, path.Root("index_name"), "source-index"); diags.HasError() {
+		t.Fatalf("failed to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: values.Raw}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: values.Raw.Copy()}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state IndexSettingsDataSourceModel
+	resp.State.Get(ctx, &state)
+	settings := state.Settings
+	if settings.InferenceType.ValueString() != "marqo.GPU" || settings.StorageClass.ValueString() != "marqo.balanced" {
+		t.Errorf("expected normalized inference type and storage class, got %s and %s", settings.InferenceType, settings.StorageClass)
+	}
+	if settings.ModelProperties == nil || settings.ModelProperties.Dimensions.ValueInt64() != 512 {
+		t.Errorf("unexpected model properties: %+v", settings.ModelProperties)
+	}
+	if settings.VideoPreprocessing == nil || settings.VideoPreprocessing.SplitLength.ValueInt64() != 20 {
+		t.Errorf("unexpected video preprocessing: %+v", settings.VideoPreprocessing)
+	}
+	if settings.AudioPreprocessing != nil {
+		t.Errorf("expected no audio preprocessing, got %+v", settings.AudioPreprocessing)
+	}
+
+	expectedJSON := `{"inferenceType":"GPU","model":"my-model","modelProperties":{"dimensions":512,"name":"ViT-B-32","type":"open_clip"},` +
+		`"numberOfInferences":1,"numberOfReplicas":1,"numberOfShards":2,"storageClass":"BALANCED","textChunkPrefix":"passage: ",` +
+		`"type":"unstructured","videoPreprocessing":{"splitLength":20,"splitOverlap":3}}`
+	if state.SettingsJSON.ValueString() != expectedJSON {
+		t.Errorf("expected settings_json %s, got %s", expectedJSON, state.SettingsJSON.ValueString())
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &indexStatsDataSource{}
+	_ datasource.DataSourceWithConfigure = &indexStatsDataSource{}
+)
+
+// ReadIndexStatsDataSource is a helper function to simplify the provider implementation.
+func ReadIndexStatsDataSource() datasource.DataSource {
+	return &indexStatsDataSource{}
+}
+
+// indexStatsDataSource is the data source implementation.
+type indexStatsDataSource struct {
+	marqoClient *go_marqo.Client
+}
+
+// IndexStatsDataSourceModel maps the data source schema data.
+type IndexStatsDataSourceModel struct {
+	IndexName             types.String  `tfsdk:"index_name"`
+	NumberOfDocuments     types.Int64   `tfsdk:"number_of_documents"`
+	NumberOfVectors       types.Int64   `tfsdk:"number_of_vectors"`
+	MemoryUsedPercentage  types.Float64 `tfsdk:"memory_used_percentage"`
+	StorageUsedPercentage types.Float64 `tfsdk:"storage_used_percentage"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *indexStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*go_marqo.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *go_marqo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.marqoClient = client
+}
+
+// Metadata returns the data source type name.
+func (d *indexStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index_stats"
+}
+
+// Schema defines the schema for the data source.
+func (d *indexStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the document count and resource usage of a Marqo index, for example to use in check blocks.",
+		Attributes: map[string]schema.Attribute{
+			"index_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the index.",
+			},
+			"number_of_documents": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of documents in the index.",
+			},
+			"number_of_vectors": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of vectors in the index.",
+			},
+			"memory_used_percentage": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The percentage of the index's backend memory in use.",
+			},
+			"storage_used_percentage": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The percentage of the index's backend storage in use.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *indexStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model IndexStatsDataSourceModel
+	diags := req.Config.GetAttribute(ctx, path.Root("index_name"), &model.IndexName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexName := model.IndexName.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Getting stats of index %s", indexName))
+	stats, err := d.marqoClient.GetIndexStats(indexName)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Get Index Stats", fmt.Sprintf("Could not get stats of index %s: %s", indexName, err))
+		return
+	}
+
+	model.NumberOfDocuments = types.Int64Value(stats.NumberOfDocuments)
+	model.NumberOfVectors = types.Int64Value(stats.NumberOfVectors)
+	model.MemoryUsedPercentage = types.Float64Value(stats.Backend.MemoryUsedPercentage)
+	model.StorageUsedPercentage = types.Float64Value(stats.Backend.StorageUsedPercentage)
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
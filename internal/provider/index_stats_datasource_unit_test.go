@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIndexStatsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client := newTestStatsServer(t, go_marqo.IndexStats{
+		NumberOfDocuments: 100,
+		NumberOfVectors:   250,
+		Backend:           go_marqo.IndexStatsBackend{MemoryUsedPercentage: 42.5, StorageUsedPercentage: 81.2},
+	})
+
+	d := &indexStatsDataSource{marqoClient: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	values := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := values.SetAttribute(ctx, path.Root("index_name"), "test-index"); diags.HasError() {
+		t.Fatalf("failed to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: values.Raw}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: values.Raw.Copy()}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state IndexStatsDataSourceModel
+	resp.State.Get(ctx, &state)
+	if state.NumberOfDocuments.ValueInt64() != 100 || state.NumberOfVectors.ValueInt64() != 250 {
+		t.Errorf("unexpected counts: %+v", state)
+	}
+	if state.MemoryUsedPercentage.ValueFloat64() != 42.5 || state.StorageUsedPercentage.ValueFloat64() != 81.2 {
+		t.Errorf("unexpected usage: %+v", state)
+	}
+}
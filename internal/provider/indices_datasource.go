@@ -26,9 +26,17 @@ func ReadIndicesDataSource() datasource.DataSource {
 
 // orderResourceModel maps the resource schema data.
 type allIndicesResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Items       []indexModel `tfsdk:"items"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID            types.String      `tfsdk:"id"`
+	NameRegex     types.String      `tfsdk:"name_regex"`
+	Status        types.String      `tfsdk:"status"`
+	Type          types.String      `tfsdk:"type"`
+	Model         types.String      `tfsdk:"model"`
+	InferenceType types.String      `tfsdk:"inference_type"`
+	Typed         types.Bool        `tfsdk:"typed"`
+	IncludeStats  types.Bool        `tfsdk:"include_stats"`
+	Items         []indexModel      `tfsdk:"items"`
+	TypedItems    []typedIndexModel `tfsdk:"typed_items"`
+	LastUpdated   types.String      `tfsdk:"last_updated"`
 }
 
 // indexModel maps index detail data.
@@ -62,6 +70,7 @@ type indexModel struct {
 	AnnParameters                *AnnParametersModel      `tfsdk:"ann_parameters"`
 	MarqoVersion                 types.String             `tfsdk:"marqo_version"`
 	FilterStringMaxLength        types.String             `tfsdk:"filter_string_max_length"`
+	Stats                        *indexStatsModel         `tfsdk:"stats"`
 }
 
 type ModelPropertiesModel struct {
@@ -161,8 +170,40 @@ func (d *indicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Computed:    true,
 				Description: "The last time the resource was updated.",
 			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return indexes whose name matches this regular expression.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return indexes with this status, e.g. READY.",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return indexes of this type: structured, unstructured or semi-structured.",
+			},
+			"model": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return indexes that use this model.",
+			},
+			"inference_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return indexes with this inference type, e.g. marqo.GPU.",
+			},
+			"typed": schema.BoolAttribute{
+				Optional: true,
+				Description: "Also return the indexes in typed_items, with numbers as numbers. " +
+					"items keeps its string values for compatibility. Default is false.",
+			},
+			"include_stats": schema.BoolAttribute{
+				Optional: true,
+				Description: "Also read the document counts and backend utilisation of each index into stats. " +
+					"An index whose stats cannot be read is reported with a warning. Default is false.",
+			},
+			"typed_items": typedItemsSchema(),
 			"items": schema.ListNestedAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "The indexes that pass the filters. Numbers are returned as strings; see typed_items.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"index_name": schema.StringAttribute{
@@ -173,6 +214,7 @@ func (d *indicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 							Computed:    true,
 							Description: "The version of Marqo used by the index",
 						},
+						"stats": indexStatsSchema(),
 						"marqo_endpoint": schema.StringAttribute{
 							Computed:    true,
 							Description: "The Marqo endpoint used by the index",
@@ -487,13 +529,19 @@ func (d *indicesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	tflog.Debug(context.TODO(), "Calling marqo client ListIndices")
 	var model allIndicesResourceModel
 
-	// Retrieve the id from the Terraform configuration
-	diags := req.Config.GetAttribute(ctx, path.Root("id"), &model.ID)
+	// Retrieve the id and filters from the Terraform configuration
+	diags := req.Config.Get(ctx, &model)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	filter, err := newIndexFilter(model)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", err.Error())
+		return
+	}
+
 	indices, err := d.marqoClient.ListIndices()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
@@ -515,8 +563,16 @@ func (d *indicesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		"PERFORMANCE": "marqo.performance",
 	}
 
-	items := make([]indexModel, len(indices))
-	for i, indexDetail := range indices {
+	var matched []go_marqo.IndexDetail
+	for _, indexDetail := range indices {
+		if filter.matches(indexDetail) {
+			matched = append(matched, indexDetail)
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("%d of %d indices pass the filters", len(matched), len(indices)))
+
+	items := make([]indexModel, len(matched))
+	for i, indexDetail := range matched {
 		inferenceType := indexDetail.InferenceType
 		if mappedValue, exists := inferenceTypeMap[inferenceType]; exists {
 			inferenceType = mappedValue
@@ -604,5 +660,32 @@ func (d *indicesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// Set the last_updated field
 	model.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 	model.Items = items
+	model.TypedItems = nil
+	if model.Typed.ValueBool() {
+		model.TypedItems = make([]typedIndexModel, len(matched))
+		for i, indexDetail := range matched {
+			model.TypedItems[i] = typedIndexFromDetail(indexDetail)
+		}
+	}
+
+	if model.IncludeStats.ValueBool() {
+		indexNames := make([]string, len(matched))
+		for i, indexDetail := range matched {
+			indexNames[i] = indexDetail.IndexName
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Reading stats of %d indices", len(indexNames)))
+		for i, result := range fetchIndexStats(indexNames, indexStatsWorkers, d.marqoClient.GetIndexStats) {
+			if result.err != nil {
+				resp.Diagnostics.AddWarning(
+					"Failed to Get Index Stats",
+					fmt.Sprintf("Could not get stats of index %s, so its stats are null: %s", indexNames[i], result.err))
+				continue
+			}
+			model.Items[i].Stats = result.stats
+			if model.TypedItems != nil {
+				model.TypedItems[i].Stats = result.stats
+			}
+		}
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"fmt"
+	"marqo/go_marqo"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// indexFilter selects the indexes returned by marqo_read_indices. Empty fields match any index.
+type indexFilter struct {
+	nameRegex     *regexp.Regexp
+	status        string
+	indexType     string
+	model         string
+	inferenceType string
+}
+
+// newIndexFilter builds a filter from the data source configuration.
+func newIndexFilter(model allIndicesResourceModel) (indexFilter, error) {
+	filter := indexFilter{
+		status:        model.Status.ValueString(),
+		indexType:     model.Type.ValueString(),
+		model:         model.Model.ValueString(),
+		inferenceType: model.InferenceType.ValueString(),
+	}
+	if nameRegex := model.NameRegex.ValueString(); nameRegex != "" {
+		compiled, err := regexp.Compile(nameRegex)
+		if err != nil {
+			return indexFilter{}, fmt.Errorf("name_regex is not a valid regular expression: %v", err)
+		}
+		filter.nameRegex = compiled
+	}
+	return filter, nil
+}
+
+// configInferenceType returns an inference type in the form used in configuration.
+func configInferenceType(inferenceType string) string {
+	if mappedValue, exists := inferenceTypeMap[inferenceType]; exists {
+		return mappedValue
+	}
+	return inferenceType
+}
+
+// matches reports whether an index passes the filter. Status and type are compared case-insensitively,
+// and inference types may be given either as configured or as reported by the API.
+func (f indexFilter) matches(index go_marqo.IndexDetail) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(index.IndexName) {
+		return false
+	}
+	if f.status != "" && !strings.EqualFold(f.status, index.IndexStatus) {
+		return false
+	}
+	if f.indexType != "" && !strings.EqualFold(f.indexType, index.Type) {
+		return false
+	}
+	if f.model != "" && f.model != index.Model {
+		return false
+	}
+	if f.inferenceType != "" && configInferenceType(f.inferenceType) != configInferenceType(index.InferenceType) {
+		return false
+	}
+	return true
+}
+
+// typedIndexModel is an item of typed_items: the same attributes as items, with numbers as Int64.
+type typedIndexModel struct {
+	Created                      types.String                   `tfsdk:"created"`
+	IndexName                    types.String                   `tfsdk:"index_name"`
+	NumberOfShards               types.Int64                    `tfsdk:"number_of_shards"`
+	NumberOfReplicas             types.Int64                    `tfsdk:"number_of_replicas"`
+	IndexStatus                  types.String                   `tfsdk:"index_status"`
+	AllFields                    []AllFieldInput                `tfsdk:"all_fields"`
+	TensorFields                 []string                       `tfsdk:"tensor_fields"`
+	NumberOfInferences           types.Int64                    `tfsdk:"number_of_inferences"`
+	StorageClass                 types.String                   `tfsdk:"storage_class"`
+	InferenceType                types.String                   `tfsdk:"inference_type"`
+	DocsCount                    types.Int64                    `tfsdk:"docs_count"`
+	StoreSize                    types.String                   `tfsdk:"store_size"`
+	DocsDeleted                  types.Int64                    `tfsdk:"docs_deleted"`
+	SearchQueryTotal             types.Int64                    `tfsdk:"search_query_total"`
+	TreatUrlsAndPointersAsImages types.Bool                     `tfsdk:"treat_urls_and_pointers_as_images"`
+	TreatUrlsAndPointersAsMedia  types.Bool                     `tfsdk:"treat_urls_and_pointers_as_media"`
+	MarqoEndpoint                types.String                   `tfsdk:"marqo_endpoint"`
+	Type                         types.String                   `tfsdk:"type"`
+	VectorNumericType            types.String                   `tfsdk:"vector_numeric_type"`
+	Model                        types.String                   `tfsdk:"model"`
+	ModelProperties              *ModelPropertiesModelCreate    `tfsdk:"model_properties"`
+	NormalizeEmbeddings          types.Bool                     `tfsdk:"normalize_embeddings"`
+	TextPreprocessing            *TextPreprocessingModelCreate  `tfsdk:"text_preprocessing"`
+	ImagePreprocessing           *ImagePreprocessingModel       `tfsdk:"image_preprocessing"`
+	VideoPreprocessing           *VideoPreprocessingModelCreate `tfsdk:"video_preprocessing"`
+	AudioPreprocessing           *AudioPreprocessingModelCreate `tfsdk:"audio_preprocessing"`
+	AnnParameters                *AnnParametersModelCreate      `tfsdk:"ann_parameters"`
+	MarqoVersion                 types.String                   `tfsdk:"marqo_version"`
+	FilterStringMaxLength        types.Int64                    `tfsdk:"filter_string_max_length"`
+	Stats                        *indexStatsModel               `tfsdk:"stats"`
+}
+
+// countValue parses a count the API reports as a string, returning null if it is not a number.
+func countValue(count string) types.Int64 {
+	value, err := strconv.ParseInt(count, 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// typedIndexFromDetail converts an index for typed_items. Settings are converted as for the
+// marqo_index data source, so optional settings are filled in with Marqo's defaults.
+func typedIndexFromDetail(indexDetail go_marqo.IndexDetail) typedIndexModel {
+	index := indexModelFromDetail(indexDetail, nil)
+	configFormIndexSettings(&index.Settings)
+	settings := index.Settings
+
+	return typedIndexModel{
+		Created:                      types.StringValue(indexDetail.Created),
+		IndexName:                    index.IndexName,
+		NumberOfShards:               settings.NumberOfShards,
+		NumberOfReplicas:             settings.NumberOfReplicas,
+		IndexStatus:                  index.IndexStatus,
+		AllFields:                    settings.AllFields,
+		TensorFields:                 settings.TensorFields,
+		NumberOfInferences:           settings.NumberOfInferences,
+		StorageClass:                 settings.StorageClass,
+		InferenceType:                settings.InferenceType,
+		DocsCount:                    countValue(indexDetail.DocsCount),
+		StoreSize:                    types.StringValue(indexDetail.StoreSize),
+		DocsDeleted:                  countValue(indexDetail.DocsDeleted),
+		SearchQueryTotal:             countValue(indexDetail.SearchQueryTotal),
+		TreatUrlsAndPointersAsImages: settings.TreatUrlsAndPointersAsImages,
+		TreatUrlsAndPointersAsMedia:  settings.TreatUrlsAndPointersAsMedia,
+		MarqoEndpoint:                index.MarqoEndpoint,
+		Type:                         settings.Type,
+		VectorNumericType:            settings.VectorNumericType,
+		Model:                        settings.Model,
+		ModelProperties:              settings.ModelProperties,
+		NormalizeEmbeddings:          settings.NormalizeEmbeddings,
+		TextPreprocessing:            settings.TextPreprocessing,
+		ImagePreprocessing:           settings.ImagePreprocessing,
+		VideoPreprocessing:           settings.VideoPreprocessing,
+		AudioPreprocessing:           settings.AudioPreprocessing,
+		AnnParameters:                settings.AnnParameters,
+		MarqoVersion:                 types.StringValue(indexDetail.MarqoVersion),
+		FilterStringMaxLength:        settings.FilterStringMaxLength,
+	}
+}
+
+// typedItemsSchema returns the schema of typed_items.
+func typedItemsSchema() schema.ListNestedAttribute {
+	settings := indexSettingsDataSourceSchema().Attributes
+	attributes := map[string]schema.Attribute{
+		"created":            schema.StringAttribute{Computed: true, Description: "The creation date of the index"},
+		"index_name":         schema.StringAttribute{Computed: true, Description: "The name of the index"},
+		"index_status":       schema.StringAttribute{Computed: true, Description: "The status of the index"},
+		"marqo_endpoint":     schema.StringAttribute{Computed: true, Description: "The Marqo endpoint used by the index"},
+		"marqo_version":      schema.StringAttribute{Computed: true, Description: "The version of Marqo used by the index"},
+		"docs_count":         schema.Int64Attribute{Computed: true, Description: "The number of documents in the index"},
+		"docs_deleted":       schema.Int64Attribute{Computed: true, Description: "The number of documents deleted from the index"},
+		"search_query_total": schema.Int64Attribute{Computed: true, Description: "The total number of search queries made on the index"},
+		"store_size":         schema.StringAttribute{Computed: true, Description: "The size of the index storage"},
+		"stats":              indexStatsSchema(),
+		"all_fields": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{Computed: true},
+					"type": schema.StringAttribute{Computed: true},
+					"features": schema.ListAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"dependent_fields": schema.MapAttribute{
+						Computed:    true,
+						ElementType: types.Float64Type,
+					},
+				},
+			},
+		},
+	}
+	// The settings have the same types as in the marqo_index data source
+	for _, name := range []string{
+		"number_of_shards", "number_of_replicas", "number_of_inferences", "tensor_fields", "storage_class",
+		"inference_type", "treat_urls_and_pointers_as_images", "treat_urls_and_pointers_as_media", "type",
+		"vector_numeric_type", "model", "model_properties", "normalize_embeddings", "text_preprocessing",
+		"image_preprocessing", "video_preprocessing", "audio_preprocessing", "ann_parameters", "filter_string_max_length",
+	} {
+		attributes[name] = settings[name]
+	}
+
+	return schema.ListNestedAttribute{
+		Computed: true,
+		Description: "The indexes that pass the filters, with numbers as numbers rather than strings. " +
+			"Only set when typed is true. Optional settings are filled in with the defaults Marqo applies.",
+		NestedObject: schema.NestedAttributeObject{Attributes: attributes},
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// indexStatsWorkers is the number of index stats requests marqo_read_indices runs at once.
+const indexStatsWorkers = 8
+
+// indexStatsModel maps the stats of an item of marqo_read_indices.
+type indexStatsModel struct {
+	NumberOfDocuments     types.Int64   `tfsdk:"number_of_documents"`
+	NumberOfVectors       types.Int64   `tfsdk:"number_of_vectors"`
+	MemoryUsedPercentage  types.Float64 `tfsdk:"memory_used_percentage"`
+	StorageUsedPercentage types.Float64 `tfsdk:"storage_used_percentage"`
+}
+
+// indexStatsSchema returns the schema of the stats of an item of marqo_read_indices.
+func indexStatsSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed: true,
+		Description: "The document counts and backend utilisation of the index. Only set when include_stats is true " +
+			"and the stats of the index could be read.",
+		Attributes: map[string]schema.Attribute{
+			"number_of_documents": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of documents in the index.",
+			},
+			"number_of_vectors": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of vectors in the index.",
+			},
+			"memory_used_percentage": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The percentage of the index's backend memory in use.",
+			},
+			"storage_used_percentage": schema.Float64Attribute{
+				Computed:    true,
+				Description: "The percentage of the index's backend storage in use.",
+			},
+		},
+	}
+}
+
+// newIndexStatsModel converts the stats reported by Marqo.
+func newIndexStatsModel(stats go_marqo.IndexStats) *indexStatsModel {
+	return &indexStatsModel{
+		NumberOfDocuments:     types.Int64Value(stats.NumberOfDocuments),
+		NumberOfVectors:       types.Int64Value(stats.NumberOfVectors),
+		MemoryUsedPercentage:  types.Float64Value(stats.Backend.MemoryUsedPercentage),
+		StorageUsedPercentage: types.Float64Value(stats.Backend.StorageUsedPercentage),
+	}
+}
+
+// indexStatsResult is the outcome of reading the stats of one index.
+type indexStatsResult struct {
+	stats *indexStatsModel
+	err   error
+}
+
+// fetchIndexStats reads the stats of each index, running up to workers requests at a time.
+// Results are returned in the order of indexNames.
+func fetchIndexStats(indexNames []string, workers int, get func(indexName string) (go_marqo.IndexStats, error)) []indexStatsResult {
+	results := make([]indexStatsResult, len(indexNames))
+	runBounded(len(indexNames), workers, func(i int) {
+		stats, err := get(indexNames[i])
+		if err != nil {
+			results[i] = indexStatsResult{err: err}
+			return
+		}
+		results[i] = indexStatsResult{stats: newIndexStatsModel(stats)}
+	})
+	return results
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFetchIndexStats(t *testing.T) {
+	indexNames := []string{"a", "b", "c", "d", "e", "f"}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	release := make(chan struct{})
+	go func() {
+		// Requests complete one at a time, so the workers overlap
+		for range indexNames {
+			release <- struct{}{}
+		}
+	}()
+
+	results := fetchIndexStats(indexNames, 2, func(indexName string) (go_marqo.IndexStats, error) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if indexName == "c" {
+			return go_marqo.IndexStats{}, errors.New("stats unavailable")
+		}
+		return go_marqo.IndexStats{NumberOfDocuments: int64(indexName[0])}, nil
+	})
+
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 requests at once, got %d", maxRunning)
+	}
+	for i, result := range results {
+		if indexNames[i] == "c" {
+			if result.err == nil || result.stats != nil {
+				t.Errorf("expected an error for index c, got %+v", result)
+			}
+			continue
+		}
+		if result.err != nil || result.stats.NumberOfDocuments.ValueInt64() != int64(indexNames[i][0]) {
+			t.Errorf("unexpected result for index %s: %+v", indexNames[i], result)
+		}
+	}
+}
+
+func TestIndicesDataSourceReadIncludeStats(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/indexes":
+			_ = json.NewEncoder(w).Encode(go_marqo.IndexResponse{Results: []go_marqo.IndexDetail{
+				{IndexName: "healthy", IndexStatus: "READY"},
+				{IndexName: "broken", IndexStatus: "READY"},
+			}})
+		case "/indexes/healthy/stats":
+			_ = json.NewEncoder(w).Encode(go_marqo.IndexStats{
+				NumberOfDocuments: 10,
+				NumberOfVectors:   30,
+				Backend:           go_marqo.IndexStatsBackend{MemoryUsedPercentage: 12.5, StorageUsedPercentage: 40},
+			})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	d := &indicesDataSource{marqoClient: &go_marqo.Client{BaseURL: server.URL, APIKey: "test-api-key"}}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	values := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	for name, value := range map[string]interface{}{"id": "test", "include_stats": true, "typed": true} {
+		if diags := values.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("failed to build config: %v", diags)
+		}
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: values.Raw}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: values.Raw.Copy()}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "index broken") {
+		t.Errorf("expected one warning for index broken, got %v", resp.Diagnostics)
+	}
+
+	var state allIndicesResourceModel
+	resp.State.Get(ctx, &state)
+	healthy := state.Items[0].Stats
+	if healthy == nil || healthy.NumberOfDocuments.ValueInt64() != 10 || healthy.StorageUsedPercentage.ValueFloat64() != 40 {
+		t.Errorf("unexpected stats for index healthy: %+v", healthy)
+	}
+	if state.TypedItems[0].Stats == nil || state.TypedItems[0].Stats.NumberOfVectors.ValueInt64() != 30 {
+		t.Errorf("unexpected typed stats for index healthy: %+v", state.TypedItems[0].Stats)
+	}
+	if state.Items[1].Stats != nil {
+		t.Errorf("expected no stats for index broken, got %+v", state.Items[1].Stats)
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIndexFilterMatches(t *testing.T) {
+	index := go_marqo.IndexDetail{
+		IndexName:     "products-prod",
+		IndexStatus:   "READY",
+		Type:          "unstructured",
+		Model:         "hf/e5-base-v2",
+		InferenceType: "GPU",
+	}
+
+	tests := []struct {
+		name     string
+		config   allIndicesResourceModel
+		expected bool
+	}{
+		{name: "no filters", config: allIndicesResourceModel{}, expected: true},
+		{name: "name regex matches", config: allIndicesResourceModel{NameRegex: types.StringValue("^products-")}, expected: true},
+		{name: "name regex does not match", config: allIndicesResourceModel{NameRegex: types.StringValue("-staging$")}, expected: false},
+		{name: "status ignores case", config: allIndicesResourceModel{Status: types.StringValue("ready")}, expected: true},
+		{name: "other status", config: allIndicesResourceModel{Status: types.StringValue("CREATING")}, expected: false},
+		{name: "type", config: allIndicesResourceModel{Type: types.StringValue("structured")}, expected: false},
+		{name: "model", config: allIndicesResourceModel{Model: types.StringValue("hf/e5-base-v2")}, expected: true},
+		{name: "configured inference type", config: allIndicesResourceModel{InferenceType: types.StringValue("marqo.GPU")}, expected: true},
+		{name: "api inference type", config: allIndicesResourceModel{InferenceType: types.StringValue("GPU")}, expected: true},
+		{name: "other inference type", config: allIndicesResourceModel{InferenceType: types.StringValue("marqo.CPU.small")}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newIndexFilter(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if filter.matches(index) != tt.expected {
+				t.Errorf("expected match to be %v", tt.expected)
+			}
+		})
+	}
+
+	if _, err := newIndexFilter(allIndicesResourceModel{NameRegex: types.StringValue("(")}); err == nil {
+		t.Error("expected an error for an invalid name_regex")
+	}
+}
+
+func TestIndicesDataSourceReadTyped(t *testing.T) {
+	ctx := context.Background()
+	client := newTestStatsServer(t, go_marqo.IndexStats{},
+		go_marqo.IndexDetail{
+			IndexName:          "products-prod",
+			IndexStatus:        "READY",
+			Type:               "unstructured",
+			InferenceType:      "GPU",
+			NumberOfShards:     2,
+			NumberOfReplicas:   1,
+			NumberOfInferences: 1,
+			DocsCount:          "1500",
+			StoreSize:          "1.2mb",
+			TextPreprocessing:  go_marqo.TextPreprocessing{SplitLength: 3, SplitMethod: "sentence", SplitOverlap: 1},
+		},
+		go_marqo.IndexDetail{IndexName: "scratch", IndexStatus: "READY", Type: "unstructured"},
+	)
+
+	d := &indicesDataSource{marqoClient: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	values := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	for name, value := range map[string]interface{}{"id": "test", "name_regex": "^products-", "typed": true} {
+		if diags := values.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("failed to build config: %v", diags)
+		}
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: values.Raw}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: values.Raw.Copy()}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state allIndicesResourceModel
+	resp.State.Get(ctx, &state)
+	if len(state.Items) != 1 || len(state.TypedItems) != 1 {
+		t.Fatalf("expected one item and one typed item, got %d and %d", len(state.Items), len(state.TypedItems))
+	}
+	if state.Items[0].NumberOfShards.ValueString() != "2" {
+		t.Errorf("expected items to keep string values, got %s", state.Items[0].NumberOfShards)
+	}
+
+	typed := state.TypedItems[0]
+	if typed.NumberOfShards.ValueInt64() != 2 || typed.NumberOfReplicas.ValueInt64() != 1 || typed.DocsCount.ValueInt64() != 1500 {
+		t.Errorf("unexpected typed counts: %+v", typed)
+	}
+	if typed.InferenceType.ValueString() != "marqo.GPU" || typed.TextPreprocessing.SplitLength.ValueInt64() != 3 {
+		t.Errorf("unexpected typed settings: %+v", typed)
+	}
+	if !typed.DocsDeleted.IsNull() {
+		t.Errorf("expected docs_deleted to be null when it is not reported, got %s", typed.DocsDeleted)
+	}
+}
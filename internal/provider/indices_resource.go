@@ -1,24 +1,46 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"marqo/go_marqo"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource                = &indicesResource{}
-	_ resource.ResourceWithConfigure   = &indicesResource{}
-	_ resource.ResourceWithImportState = &indicesResource{}
+	_ resource.Resource                   = &indicesResource{}
+	_ resource.ResourceWithConfigure      = &indicesResource{}
+	_ resource.ResourceWithImportState    = &indicesResource{}
+	_ resource.ResourceWithModifyPlan     = &indicesResource{}
+	_ resource.ResourceWithValidateConfig = &indicesResource{}
 )
 
 // ManageIndicesResource is a helper function to simplify the provider implementation.
@@ -29,14 +51,22 @@ func ManageIndicesResource() resource.Resource {
 // orderResource is the resource implementation.
 type indicesResource struct {
 	marqoClient *go_marqo.Client
+	limiter     *operationLimiter
+	pricing     *pricingTable
 }
 
 // IndexResourceModel maps the resource schema data.
 type IndexResourceModel struct {
-	IndexName     types.String       `tfsdk:"index_name"`
-	Settings      IndexSettingsModel `tfsdk:"settings"`
-	MarqoEndpoint types.String       `tfsdk:"marqo_endpoint"`
-	Timeouts      *timeouts          `tfsdk:"timeouts"`
+	IndexName        types.String       `tfsdk:"index_name"`
+	NamePrefix       types.String       `tfsdk:"name_prefix"`
+	Settings         IndexSettingsModel `tfsdk:"settings"`
+	MarqoEndpoint    types.String       `tfsdk:"marqo_endpoint"`
+	IndexStatus      types.String       `tfsdk:"index_status"`
+	DiscoveredFields types.Set          `tfsdk:"discovered_fields"`
+	AdoptExisting    types.Bool         `tfsdk:"adopt_existing"`
+	WaitForReady     types.Bool         `tfsdk:"wait_for_ready"`
+	Autoscaling      *AutoscalingModel  `tfsdk:"autoscaling"`
+	Timeouts         *timeouts          `tfsdk:"timeouts"`
 }
 
 type timeouts struct {
@@ -66,6 +96,7 @@ type IndexSettingsModel struct {
 	AudioPreprocessing           *AudioPreprocessingModelCreate `tfsdk:"audio_preprocessing"`
 	AnnParameters                *AnnParametersModelCreate      `tfsdk:"ann_parameters"`
 	FilterStringMaxLength        types.Int64                    `tfsdk:"filter_string_max_length"`
+	ExtraSettingsJSON            jsontypes.Normalized           `tfsdk:"extra_settings_json"`
 }
 
 type ModelPropertiesModelCreate struct {
@@ -122,18 +153,20 @@ func (r *indicesResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*go_marqo.Client)
+	data, ok := req.ProviderData.(*marqoResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *marqoResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.marqoClient = client
+	r.marqoClient = data.client
+	r.limiter = data.limiter
+	r.pricing = data.pricing
 }
 
 // Metadata returns the resource type name.
@@ -143,15 +176,58 @@ func (r *indicesResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *indicesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Manages a Marqo index. inference_type, number_of_inferences, number_of_shards and number_of_replicas " +
+			"are updated in place, as are fields added to a semi-structured index. Changing any other setting replaces " +
+			"the index: it is deleted together with all of its documents and created again, and documents are not " +
+			"copied to the new index. Review plans for replacements, or use name_prefix with create_before_destroy " +
+			"to keep the old index until the new one is READY.",
+		Version: indexResourceSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"index_name": schema.StringAttribute{
-				Required:    true,
-				Description: "The name of the index.",
+				Optional: true,
+				Computed: true,
+				Description: "The name of the index. Conflicts with name_prefix, and one of the two must be set. " +
+					"When name_prefix is used, this is the generated name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional: true,
+				Description: "Generate a unique index name beginning with this prefix. Since a replacement index gets a new name, " +
+					"this allows create_before_destroy, so the new index is READY before the old one is deleted. " +
+					"Documents are not copied to the replacement index.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"marqo_endpoint": schema.StringAttribute{
 				Computed:    true,
 				Description: "The Marqo endpoint used by the index",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"index_status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the index as last reported by Marqo, e.g. CREATING, MODIFYING, READY or FAILED.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"discovered_fields": discoveredFieldsSchema(),
+			"wait_for_ready": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether create and update wait for the index to become READY. Default is true. " +
+					"When false, apply returns as soon as Marqo accepts the request and the next refresh resumes the wait.",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				Description: "If an index with the same name already exists, import it into state instead of failing. " +
+					"The existing index must have settings compatible with the configuration.",
 			},
+			"autoscaling": autoscalingSchema(),
 			"timeouts": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
@@ -174,21 +250,30 @@ func (r *indicesResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "The settings for the index.",
 				Attributes: map[string]schema.Attribute{
 					"type": schema.StringAttribute{
-						Required: true,
+						Required:      true,
+						Description:   "The index type: structured, unstructured or semi-structured.",
+						PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 					},
 					"vector_numeric_type": schema.StringAttribute{
-						Optional: true,
+						Optional:      true,
+						Computed:      true,
+						Default:       stringdefault.StaticString(defaultVectorNumericType),
+						PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+						Description:   "The numeric type of stored vectors. Defaults to \"" + defaultVectorNumericType + "\", the value Marqo applies when it is not set.",
 					},
 					"number_of_inferences": schema.Int64Attribute{
 						Required: true,
 					},
-					"all_fields": schema.ListNestedAttribute{
-						Optional: true,
+					"all_fields": schema.SetNestedAttribute{
+						Optional:      true,
+						PlanModifiers: []planmodifier.Set{allFieldsRequiresReplace()},
+						Description: "The fields of a structured or semi-structured index. Fields are identified by name, so their order " +
+							"does not matter. Fields can be added to a semi-structured index in place; any other change replaces the index.",
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
 								"name": schema.StringAttribute{Optional: true},
 								"type": schema.StringAttribute{Optional: true},
-								"features": schema.ListAttribute{
+								"features": schema.SetAttribute{
 									Optional:    true,
 									ElementType: types.StringType,
 								},
@@ -201,32 +286,42 @@ func (r *indicesResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						},
 					},
 					"tensor_fields": schema.ListAttribute{
-						Optional:    true,
-						ElementType: types.StringType,
+						Optional:      true,
+						ElementType:   types.StringType,
+						PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
 					},
 					"inference_type": schema.StringAttribute{
 						Required: true,
 					},
 					"storage_class": schema.StringAttribute{
-						Required: true,
+						Required:      true,
+						PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 					},
 					"number_of_shards": schema.Int64Attribute{
-						Required: true,
+						Optional:    true,
+						Computed:    true,
+						Description: "The number of shards. Required unless managed by the autoscaling block. Shards can only be increased.",
 					},
 					"number_of_replicas": schema.Int64Attribute{
-						Required: true,
+						Optional:    true,
+						Computed:    true,
+						Description: "The number of replicas. Required unless managed by the autoscaling block. Replicas can only be increased.",
 					},
 					"treat_urls_and_pointers_as_images": schema.BoolAttribute{
-						Optional: true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
 					},
 					"treat_urls_and_pointers_as_media": schema.BoolAttribute{
-						Optional: true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
 					},
 					"model": schema.StringAttribute{
-						Required: true,
+						Required:      true,
+						PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 					},
 					"model_properties": schema.SingleNestedAttribute{
-						Optional: true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
 						Attributes: map[string]schema.Attribute{
 							"name":       schema.StringAttribute{Optional: true},
 							"dimensions": schema.Int64Attribute{Optional: true},
@@ -258,10 +353,20 @@ func (r *indicesResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						},
 					},
 					"normalize_embeddings": schema.BoolAttribute{
-						Optional: true,
+						Optional:      true,
+						Computed:      true,
+						Default:       booldefault.StaticBool(defaultNormalizeEmbeddings),
+						PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+						Description:   "Whether embeddings are normalized. Defaults to true, the value Marqo applies when it is not set.",
 					},
 					"text_preprocessing": schema.SingleNestedAttribute{
-						Optional: true,
+						Optional:      true,
+						Computed:      true,
+						Default:       objectdefault.StaticValue(defaultTextPreprocessingObject()),
+						PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
+						Description: fmt.Sprintf("How text is split into chunks before it is vectorised. Defaults to the value Marqo applies "+
+							"when it is not set: split_length = %d, split_method = \"%s\", split_overlap = %d.",
+							defaultSplitLength, defaultSplitMethod, defaultSplitOverlap),
 						Attributes: map[string]schema.Attribute{
 							"split_length":  schema.Int64Attribute{Optional: true},
 							"split_method":  schema.StringAttribute{Optional: true},
@@ -269,27 +374,36 @@ func (r *indicesResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						},
 					},
 					"image_preprocessing": schema.SingleNestedAttribute{
-						Optional: true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
 						Attributes: map[string]schema.Attribute{
 							"patch_method": schema.StringAttribute{Optional: true},
 						},
 					},
 					"video_preprocessing": schema.SingleNestedAttribute{
-						Optional: true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
 						Attributes: map[string]schema.Attribute{
 							"split_length":  schema.Int64Attribute{Optional: true},
 							"split_overlap": schema.Int64Attribute{Optional: true},
 						},
 					},
 					"audio_preprocessing": schema.SingleNestedAttribute{
-						Optional: true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
 						Attributes: map[string]schema.Attribute{
 							"split_length":  schema.Int64Attribute{Optional: true},
 							"split_overlap": schema.Int64Attribute{Optional: true},
 						},
 					},
 					"ann_parameters": schema.SingleNestedAttribute{
-						Optional: true,
+						Optional:      true,
+						Computed:      true,
+						Default:       objectdefault.StaticValue(defaultAnnParametersObject()),
+						PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
+						Description: fmt.Sprintf("Parameters of the approximate nearest neighbour index. Defaults to the value Marqo applies "+
+							"when it is not set: space_type = \"%s\", ef_construction = %d, m = %d.",
+							defaultSpaceType, defaultEfConstruction, defaultM),
 						Attributes: map[string]schema.Attribute{
 							"space_type": schema.StringAttribute{
 								Optional: true,
@@ -304,7 +418,17 @@ func (r *indicesResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						},
 					},
 					"filter_string_max_length": schema.Int64Attribute{
-						Optional: true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+					},
+					"extra_settings_json": schema.StringAttribute{
+						Optional:   true,
+						CustomType: jsontypes.NormalizedType{},
+						Description: "A JSON object of index settings that are added to the create request, for Marqo options " +
+							"this provider does not support yet. It cannot set a key that an attribute above manages, such as " +
+							"numberOfShards or annParameters. Changing it replaces the index; formatting changes are applied " +
+							"without touching the index.",
+						PlanModifiers: []planmodifier.String{jsonRequiresReplace()},
 					},
 				},
 			},
@@ -312,6 +436,59 @@ func (r *indicesResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	}
 }
 
+// ValidateConfig checks that exactly one of index_name and name_prefix is set, that
+// extra_settings_json is a JSON object that leaves the typed settings alone, and that shard
+// and replica counts are either configured or managed by autoscaling.
+func (r *indicesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config IndexResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.IndexName.IsNull() && config.NamePrefix.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("index_name"),
+			"Missing Attribute Configuration",
+			"One of index_name or name_prefix must be set.")
+	}
+	if !config.IndexName.IsNull() && !config.NamePrefix.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_prefix"),
+			"Conflicting Attribute Configuration",
+			"Only one of index_name or name_prefix can be set.")
+	}
+
+	if !config.Settings.ExtraSettingsJSON.IsNull() && !config.Settings.ExtraSettingsJSON.IsUnknown() {
+		extra, err := parseExtraSettings(config.Settings.ExtraSettingsJSON.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settings").AtName("extra_settings_json"),
+				"Invalid Extra Settings JSON",
+				err.Error())
+		}
+		for _, key := range typedSettingConflicts(extra) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settings").AtName("extra_settings_json"),
+				"Conflicting Extra Settings JSON",
+				fmt.Sprintf("extra_settings_json sets %q, which is managed by settings.%s. Set it with settings.%s instead.",
+					key, typedSettings[key], typedSettings[key]))
+		}
+	}
+
+	validateAutoscalingConfig(config, &resp.Diagnostics)
+}
+
+// generateIndexName returns a unique index name beginning with prefix.
+func generateIndexName(prefix string) (string, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("error generating index name: %w", err)
+	}
+	return prefix + hex.EncodeToString(suffix), nil
+}
+
 // Utility function to convert standard Go string to types.Int64 .
 func StringToInt64(str string) types.Int64 {
 	intVal, err := strconv.ParseInt(str, 10, 64)
@@ -357,6 +534,43 @@ func validateAndConstructAllFields(allFieldsInput []AllFieldInput) ([]map[string
 	return allFields, nil
 }
 
+// allFieldsByName indexes all_fields by field name, with features sorted, so that the same
+// fields listed in a different order compare equal.
+func allFieldsByName(allFieldsInput []AllFieldInput) map[string]map[string]interface{} {
+	byName := make(map[string]map[string]interface{}, len(allFieldsInput))
+	for _, field := range convertAllFieldsToMap(allFieldsInput) {
+		features, _ := field["features"].([]string)
+		sort.Strings(features)
+		name, _ := field["name"].(string)
+		byName[name] = field
+	}
+	return byName
+}
+
+// allFieldsChanges describes the fields added, removed or changed between two all_fields
+// values, ignoring the order of fields and features. It returns nil when they are equal.
+func allFieldsChanges(from []AllFieldInput, to []AllFieldInput) []string {
+	fromByName := allFieldsByName(from)
+	toByName := allFieldsByName(to)
+
+	var changes []string
+	for name, field := range toByName {
+		existing, exists := fromByName[name]
+		if !exists {
+			changes = append(changes, fmt.Sprintf("added field %q", name))
+		} else if !reflect.DeepEqual(existing, field) {
+			changes = append(changes, fmt.Sprintf("changed field %q", name))
+		}
+	}
+	for name := range fromByName {
+		if _, exists := toByName[name]; !exists {
+			changes = append(changes, fmt.Sprintf("removed field %q", name))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
 // Utility function to convert []AllFieldInput to a format suitable for settings map.
 func convertAllFieldsToMap(allFieldsInput []AllFieldInput) []map[string]interface{} {
 	allFields := []map[string]interface{}{}
@@ -478,130 +692,242 @@ func convertModelPropertiesToResource(props *go_marqo.ModelProperties) *ModelPro
 	return model
 }
 
+// Defaults Marqo applies to optional settings that are not provided when an index is created.
+const (
+	defaultVectorNumericType   = "float"
+	defaultNormalizeEmbeddings = true
+	defaultSplitLength         = 2
+	defaultSplitMethod         = "sentence"
+	defaultSplitOverlap        = 0
+	defaultSpaceType           = "prenormalized-angular"
+	defaultEfConstruction      = 512
+	defaultM                   = 16
+
+	// Defaults for unstructured indexes only
+	defaultFilterStringMaxLength        = 50
+	defaultTreatUrlsAndPointersAsImages = false
+	defaultTreatUrlsAndPointersAsMedia  = false
+)
+
+// defaultTextPreprocessing returns the text preprocessing Marqo applies by default.
+func defaultTextPreprocessing() *TextPreprocessingModelCreate {
+	return &TextPreprocessingModelCreate{
+		SplitLength:  types.Int64Value(defaultSplitLength),
+		SplitMethod:  types.StringValue(defaultSplitMethod),
+		SplitOverlap: types.Int64Value(defaultSplitOverlap),
+	}
+}
+
+// defaultAnnParameters returns the ANN parameters Marqo applies by default.
+func defaultAnnParameters() *AnnParametersModelCreate {
+	return &AnnParametersModelCreate{
+		SpaceType: types.StringValue(defaultSpaceType),
+		Parameters: &ParametersModel{
+			EfConstruction: types.Int64Value(defaultEfConstruction),
+			M:              types.Int64Value(defaultM),
+		},
+	}
+}
+
+// Object types of the text_preprocessing and ann_parameters schema attributes.
+var (
+	textPreprocessingAttrTypes = map[string]attr.Type{
+		"split_length":  types.Int64Type,
+		"split_method":  types.StringType,
+		"split_overlap": types.Int64Type,
+	}
+	annParametersParametersAttrTypes = map[string]attr.Type{
+		"ef_construction": types.Int64Type,
+		"m":               types.Int64Type,
+	}
+	annParametersAttrTypes = map[string]attr.Type{
+		"space_type": types.StringType,
+		"parameters": types.ObjectType{AttrTypes: annParametersParametersAttrTypes},
+	}
+)
+
+// defaultTextPreprocessingObject returns defaultTextPreprocessing as a schema default.
+func defaultTextPreprocessingObject() types.Object {
+	return types.ObjectValueMust(textPreprocessingAttrTypes, map[string]attr.Value{
+		"split_length":  types.Int64Value(defaultSplitLength),
+		"split_method":  types.StringValue(defaultSplitMethod),
+		"split_overlap": types.Int64Value(defaultSplitOverlap),
+	})
+}
+
+// defaultAnnParametersObject returns defaultAnnParameters as a schema default.
+func defaultAnnParametersObject() types.Object {
+	return types.ObjectValueMust(annParametersAttrTypes, map[string]attr.Value{
+		"space_type": types.StringValue(defaultSpaceType),
+		"parameters": types.ObjectValueMust(annParametersParametersAttrTypes, map[string]attr.Value{
+			"ef_construction": types.Int64Value(defaultEfConstruction),
+			"m":               types.Int64Value(defaultM),
+		}),
+	})
+}
+
+// inferenceTypeMap maps the inference types reported by the API to the values used in configuration.
+var inferenceTypeMap = map[string]string{
+	"CPU":       "marqo.CPU.large", // verify this
+	"CPU.SMALL": "marqo.CPU.small",
+	"CPU.LARGE": "marqo.CPU.large",
+	"GPU":       "marqo.GPU",
+}
+
+// storageClassMap maps the storage classes reported by the API to the values used in configuration.
+var storageClassMap = map[string]string{
+	"BASIC":       "marqo.basic",
+	"BALANCED":    "marqo.balanced",
+	"PERFORMANCE": "marqo.performance",
+}
+
+// normalizeIndexSettings rewrites API aliases for inference type and storage class
+// into the form used in configuration.
+func normalizeIndexSettings(settings *IndexSettingsModel) {
+	if !settings.InferenceType.IsNull() {
+		if mappedValue, exists := inferenceTypeMap[settings.InferenceType.ValueString()]; exists {
+			settings.InferenceType = types.StringValue(mappedValue)
+		}
+	}
+
+	if !settings.StorageClass.IsNull() {
+		if mappedValue, exists := storageClassMap[settings.StorageClass.ValueString()]; exists {
+			settings.StorageClass = types.StringValue(mappedValue)
+		}
+	}
+}
+
 func (r *indicesResource) findAndCreateState(indices []go_marqo.IndexDetail, indexName string, existingTimeouts *timeouts) (*IndexResourceModel, bool) {
 	for _, indexDetail := range indices {
 		if indexDetail.IndexName == indexName {
-			// Create a new model with proper null handling
-			model := &IndexResourceModel{
-				IndexName:     types.StringValue(indexDetail.IndexName),
-				MarqoEndpoint: types.StringValue(indexDetail.MarqoEndpoint),
-				Timeouts:      existingTimeouts,
-				Settings: IndexSettingsModel{
-					Type:               types.StringValue(indexDetail.Type),
-					InferenceType:      types.StringValue(indexDetail.InferenceType),
-					NumberOfInferences: types.Int64Value(indexDetail.NumberOfInferences),
-					StorageClass:       types.StringValue(indexDetail.StorageClass),
-					NumberOfShards:     types.Int64Value(indexDetail.NumberOfShards),
-					NumberOfReplicas:   types.Int64Value(indexDetail.NumberOfReplicas),
-					Model:              types.StringValue(indexDetail.Model),
-					ImagePreprocessing: &ImagePreprocessingModel{
-						PatchMethod: types.StringValue(indexDetail.ImagePreprocessing.PatchMethod),
-					},
-				},
-			}
-
-			// Handle Bool fields
-			if indexDetail.TreatUrlsAndPointersAsImages == nil {
-				model.Settings.TreatUrlsAndPointersAsImages = types.BoolNull()
-			} else {
-				model.Settings.TreatUrlsAndPointersAsImages = types.BoolValue(*indexDetail.TreatUrlsAndPointersAsImages)
-			}
-			if indexDetail.TreatUrlsAndPointersAsMedia == nil {
-				model.Settings.TreatUrlsAndPointersAsMedia = types.BoolNull()
-			} else {
-				model.Settings.TreatUrlsAndPointersAsMedia = types.BoolValue(*indexDetail.TreatUrlsAndPointersAsMedia)
-			}
-			if indexDetail.NormalizeEmbeddings == nil {
-				model.Settings.NormalizeEmbeddings = types.BoolNull()
-			} else {
-				model.Settings.NormalizeEmbeddings = types.BoolValue(*indexDetail.NormalizeEmbeddings)
-			}
+			return indexModelFromDetail(indexDetail, existingTimeouts), true
+		}
+	}
+	return nil, false
+}
 
-			// Handle optional string fields
-			if indexDetail.VectorNumericType != "" {
-				model.Settings.VectorNumericType = types.StringValue(indexDetail.VectorNumericType)
-			} else {
-				model.Settings.VectorNumericType = types.StringNull()
-			}
+// indexModelFromDetail builds the state of an index from the details reported by Marqo.
+func indexModelFromDetail(indexDetail go_marqo.IndexDetail, existingTimeouts *timeouts) *IndexResourceModel {
+	// Create a new model with proper null handling
+	model := &IndexResourceModel{
+		IndexName:        types.StringValue(indexDetail.IndexName),
+		MarqoEndpoint:    types.StringValue(indexDetail.MarqoEndpoint),
+		IndexStatus:      types.StringValue(indexDetail.IndexStatus),
+		Timeouts:         existingTimeouts,
+		DiscoveredFields: noDiscoveredFields(),
+		Settings: IndexSettingsModel{
+			Type:               types.StringValue(indexDetail.Type),
+			InferenceType:      types.StringValue(indexDetail.InferenceType),
+			NumberOfInferences: types.Int64Value(indexDetail.NumberOfInferences),
+			StorageClass:       types.StringValue(indexDetail.StorageClass),
+			NumberOfShards:     types.Int64Value(indexDetail.NumberOfShards),
+			NumberOfReplicas:   types.Int64Value(indexDetail.NumberOfReplicas),
+			Model:              types.StringValue(indexDetail.Model),
+			ImagePreprocessing: &ImagePreprocessingModel{
+				PatchMethod: types.StringValue(indexDetail.ImagePreprocessing.PatchMethod),
+			},
+		},
+	}
 
-			// Handle optional numeric fields
-			if indexDetail.FilterStringMaxLength > 0 {
-				model.Settings.FilterStringMaxLength = types.Int64Value(indexDetail.FilterStringMaxLength)
-			} else {
-				model.Settings.FilterStringMaxLength = types.Int64Null()
-			}
+	// Handle Bool fields
+	if indexDetail.TreatUrlsAndPointersAsImages == nil {
+		model.Settings.TreatUrlsAndPointersAsImages = types.BoolNull()
+	} else {
+		model.Settings.TreatUrlsAndPointersAsImages = types.BoolValue(*indexDetail.TreatUrlsAndPointersAsImages)
+	}
+	if indexDetail.TreatUrlsAndPointersAsMedia == nil {
+		model.Settings.TreatUrlsAndPointersAsMedia = types.BoolNull()
+	} else {
+		model.Settings.TreatUrlsAndPointersAsMedia = types.BoolValue(*indexDetail.TreatUrlsAndPointersAsMedia)
+	}
+	if indexDetail.NormalizeEmbeddings == nil {
+		model.Settings.NormalizeEmbeddings = types.BoolNull()
+	} else {
+		model.Settings.NormalizeEmbeddings = types.BoolValue(*indexDetail.NormalizeEmbeddings)
+	}
 
-			// Handle model properties
-			if !reflect.DeepEqual(indexDetail.ModelProperties, go_marqo.ModelProperties{}) {
-				model.Settings.ModelProperties = convertModelPropertiesToResource(&indexDetail.ModelProperties)
-			} else {
-				model.Settings.ModelProperties = nil
-			}
+	// Handle optional string fields
+	if indexDetail.VectorNumericType != "" {
+		model.Settings.VectorNumericType = types.StringValue(indexDetail.VectorNumericType)
+	} else {
+		model.Settings.VectorNumericType = types.StringNull()
+	}
 
-			// Handle AllFields
-			if len(indexDetail.AllFields) > 0 {
-				model.Settings.AllFields = ConvertMarqoAllFieldInputs(indexDetail.AllFields)
-			} else {
-				model.Settings.AllFields = nil
-			}
+	// Handle optional numeric fields
+	if indexDetail.FilterStringMaxLength > 0 {
+		model.Settings.FilterStringMaxLength = types.Int64Value(indexDetail.FilterStringMaxLength)
+	} else {
+		model.Settings.FilterStringMaxLength = types.Int64Null()
+	}
 
-			// Handle TensorFields
-			if len(indexDetail.TensorFields) > 0 {
-				model.Settings.TensorFields = indexDetail.TensorFields
-			} else {
-				model.Settings.TensorFields = nil
-			}
+	// Handle model properties
+	if !reflect.DeepEqual(indexDetail.ModelProperties, go_marqo.ModelProperties{}) {
+		model.Settings.ModelProperties = convertModelPropertiesToResource(&indexDetail.ModelProperties)
+	} else {
+		model.Settings.ModelProperties = nil
+	}
 
-			// Handle TextPreprocessing
-			if indexDetail.TextPreprocessing == (go_marqo.TextPreprocessing{}) {
-				model.Settings.TextPreprocessing = nil
-			} else {
-				model.Settings.TextPreprocessing = &TextPreprocessingModelCreate{
-					SplitLength:  types.Int64Value(indexDetail.TextPreprocessing.SplitLength),
-					SplitMethod:  types.StringValue(indexDetail.TextPreprocessing.SplitMethod),
-					SplitOverlap: types.Int64Value(indexDetail.TextPreprocessing.SplitOverlap),
-				}
-			}
+	// Handle AllFields
+	if len(indexDetail.AllFields) > 0 {
+		model.Settings.AllFields = ConvertMarqoAllFieldInputs(indexDetail.AllFields)
+	} else {
+		model.Settings.AllFields = nil
+	}
 
-			// Handle VideoPreprocessing
-			if indexDetail.VideoPreprocessing.SplitLength > 0 || indexDetail.VideoPreprocessing.SplitOverlap > 0 {
-				model.Settings.VideoPreprocessing = &VideoPreprocessingModelCreate{
-					SplitLength:  types.Int64Value(indexDetail.VideoPreprocessing.SplitLength),
-					SplitOverlap: types.Int64Value(indexDetail.VideoPreprocessing.SplitOverlap),
-				}
-			} else {
-				model.Settings.VideoPreprocessing = nil
-			}
+	// Handle TensorFields
+	if len(indexDetail.TensorFields) > 0 {
+		model.Settings.TensorFields = indexDetail.TensorFields
+	} else {
+		model.Settings.TensorFields = nil
+	}
 
-			// Handle AudioPreprocessing
-			if indexDetail.AudioPreprocessing.SplitLength > 0 || indexDetail.AudioPreprocessing.SplitOverlap > 0 {
-				model.Settings.AudioPreprocessing = &AudioPreprocessingModelCreate{
-					SplitLength:  types.Int64Value(indexDetail.AudioPreprocessing.SplitLength),
-					SplitOverlap: types.Int64Value(indexDetail.AudioPreprocessing.SplitOverlap),
-				}
-			} else {
-				model.Settings.AudioPreprocessing = nil
-			}
+	// Handle TextPreprocessing
+	if indexDetail.TextPreprocessing == (go_marqo.TextPreprocessing{}) {
+		model.Settings.TextPreprocessing = nil
+	} else {
+		model.Settings.TextPreprocessing = &TextPreprocessingModelCreate{
+			SplitLength:  types.Int64Value(indexDetail.TextPreprocessing.SplitLength),
+			SplitMethod:  types.StringValue(indexDetail.TextPreprocessing.SplitMethod),
+			SplitOverlap: types.Int64Value(indexDetail.TextPreprocessing.SplitOverlap),
+		}
+	}
 
-			// Handle AnnParameters
-			if indexDetail.AnnParameters.SpaceType != "" ||
-				indexDetail.AnnParameters.Parameters.EfConstruction > 0 ||
-				indexDetail.AnnParameters.Parameters.M > 0 {
-				model.Settings.AnnParameters = &AnnParametersModelCreate{
-					SpaceType: types.StringValue(indexDetail.AnnParameters.SpaceType),
-					Parameters: &ParametersModel{
-						EfConstruction: types.Int64Value(indexDetail.AnnParameters.Parameters.EfConstruction),
-						M:              types.Int64Value(indexDetail.AnnParameters.Parameters.M),
-					},
-				}
-			} else {
-				model.Settings.AnnParameters = nil
-			}
+	// Handle VideoPreprocessing
+	if indexDetail.VideoPreprocessing.SplitLength > 0 || indexDetail.VideoPreprocessing.SplitOverlap > 0 {
+		model.Settings.VideoPreprocessing = &VideoPreprocessingModelCreate{
+			SplitLength:  types.Int64Value(indexDetail.VideoPreprocessing.SplitLength),
+			SplitOverlap: types.Int64Value(indexDetail.VideoPreprocessing.SplitOverlap),
+		}
+	} else {
+		model.Settings.VideoPreprocessing = nil
+	}
 
-			return model, true
+	// Handle AudioPreprocessing
+	if indexDetail.AudioPreprocessing.SplitLength > 0 || indexDetail.AudioPreprocessing.SplitOverlap > 0 {
+		model.Settings.AudioPreprocessing = &AudioPreprocessingModelCreate{
+			SplitLength:  types.Int64Value(indexDetail.AudioPreprocessing.SplitLength),
+			SplitOverlap: types.Int64Value(indexDetail.AudioPreprocessing.SplitOverlap),
 		}
+	} else {
+		model.Settings.AudioPreprocessing = nil
+	}
+
+	// Handle AnnParameters
+	if indexDetail.AnnParameters.SpaceType != "" ||
+		indexDetail.AnnParameters.Parameters.EfConstruction > 0 ||
+		indexDetail.AnnParameters.Parameters.M > 0 {
+		model.Settings.AnnParameters = &AnnParametersModelCreate{
+			SpaceType: types.StringValue(indexDetail.AnnParameters.SpaceType),
+			Parameters: &ParametersModel{
+				EfConstruction: types.Int64Value(indexDetail.AnnParameters.Parameters.EfConstruction),
+				M:              types.Int64Value(indexDetail.AnnParameters.Parameters.M),
+			},
+		}
+	} else {
+		model.Settings.AnnParameters = nil
 	}
-	return nil, false
+
+	return model
 }
 
 func (r *indicesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -613,14 +939,30 @@ func (r *indicesResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// Check if this is likely an import operation
-	isImport := state.MarqoEndpoint.IsNull() &&
-		(state.Settings.Type.ValueString() == "" ||
-			state.Settings.InferenceType.ValueString() == "" ||
-			state.Settings.NumberOfInferences.ValueInt64() == 0)
-
-	if isImport {
-		tflog.Info(ctx, fmt.Sprintf("Detected import operation for index %s", state.IndexName.ValueString()))
+	// Resume waiting for an index that was created or updated with wait_for_ready = false
+	if isIndexStatusPending(state.IndexStatus.ValueString()) {
+		current, err := r.findIndexDetail(state.IndexName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+			return
+		}
+		// An index deleted outside of Terraform is removed from state below instead of waited for
+		if current != nil && isIndexStatusPending(current.IndexStatus) {
+			timeoutDuration := pendingStatusTimeout(ctx, state)
+			tflog.Info(ctx, fmt.Sprintf("Index %s was last seen in %s status, resuming wait for READY",
+				state.IndexName.ValueString(), state.IndexStatus.ValueString()))
+			err = r.waitForIndexStatus(ctx, state.IndexName.ValueString(), "READY", timeoutDuration, false)
+			var failedErr *indexFailedError
+			if errors.As(err, &failedErr) {
+				// The refreshed FAILED status below causes the index to be planned for replacement
+				tflog.Warn(ctx, failedErr.Error())
+			} else if err != nil {
+				resp.Diagnostics.AddError(
+					"Index Did Not Become Ready",
+					fmt.Sprintf("Index %s did not become READY: %s", state.IndexName.ValueString(), err))
+				return
+			}
+		}
 	}
 
 	tflog.Debug(ctx, "Calling marqo client ListIndices")
@@ -634,243 +976,422 @@ func (r *indicesResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	// Handle inference_type field
 	if newState != nil {
-		inferenceTypeMap := map[string]string{
-			"CPU":       "marqo.CPU.large", // verify this
-			"CPU.SMALL": "marqo.CPU.small",
-			"CPU.LARGE": "marqo.CPU.large",
-			"GPU":       "marqo.GPU",
-		}
+		configFormIndexSettings(&newState.Settings)
 
-		storaceClassMap := map[string]string{
-			"BASIC":       "marqo.basic",
-			"BALANCED":    "marqo.balanced",
-			"PERFORMANCE": "marqo.performance",
+		// marqo doesn't return timeouts, so we maintain the existing state
+		newState.Timeouts = state.Timeouts
+		newState.AdoptExisting = state.AdoptExisting
+		newState.WaitForReady = state.WaitForReady
+		newState.Autoscaling = state.Autoscaling
+		newState.NamePrefix = state.NamePrefix
+
+		splitDiscoveredFields(newState, state.Settings.AllFields)
+		preserveConfigForm(&newState.Settings, state.Settings)
+		for _, indexDetail := range indices {
+			if indexDetail.IndexName == newState.IndexName.ValueString() {
+				newState.Settings.ExtraSettingsJSON = refreshExtraSettings(state.Settings.ExtraSettingsJSON, indexDetail.Raw)
+			}
 		}
 
-		if !newState.Settings.InferenceType.IsNull() {
-			currentValue := newState.Settings.InferenceType.ValueString()
-			if mappedValue, exists := inferenceTypeMap[currentValue]; exists {
-				newState.Settings.InferenceType = types.StringValue(mappedValue)
+		// Settings changed outside of Terraform show up as ordinary diffs in the next plan
+		if !state.Settings.Type.IsNull() && !isIndexStatusPending(state.IndexStatus.ValueString()) {
+			if diffs := indexSettingsDiff(newState.Settings, state.Settings); len(diffs) > 0 {
+				resp.Diagnostics.AddWarning(
+					"Index Settings Changed Outside Terraform",
+					fmt.Sprintf("Index %s no longer matches the settings recorded in Terraform state:\n%s\n\n"+
+						"The state has been refreshed with the values reported by Marqo. If these changes were made "+
+						"intentionally, update your configuration to match; otherwise the next apply will change them back "+
+						"where the setting can be updated, or plan a replacement where it cannot.",
+						newState.IndexName.ValueString(), strings.Join(diffs, "\n")))
 			}
 		}
+	}
 
-		if !newState.Settings.StorageClass.IsNull() {
-			currentValue := newState.Settings.StorageClass.ValueString()
-			if mappedValue, exists := storaceClassMap[currentValue]; exists {
-				newState.Settings.StorageClass = types.StringValue(mappedValue)
-			}
+	if found && newState.IndexStatus.ValueString() == "FAILED" {
+		resp.Diagnostics.AddWarning(
+			"Index Has Failed",
+			fmt.Sprintf("Index %s is in FAILED status: %s\n\nThe index will be deleted and recreated on the next apply.",
+				newState.IndexName.ValueString(), failureReason(newState.IndexName.ValueString(), indices)))
+	}
+
+	// if index no longer exists in cloud, delete the state
+	if !found {
+		resp.Diagnostics.AddWarning("Resource Not Found", "The specified index does not exist in the cloud. The state will be deleted.")
+		// Then Totally Remove from terraform resources
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set the updated state
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Private state is not available when create and update call Read for their final refresh
+	if resp.Private != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, ownedIndexKey, ownedIndexValue(newState.IndexName.ValueString()))...)
+	}
+}
+
+// configFormIndexSettings rewrites settings built from the API into the form a user would write
+// in configuration: aliases are normalized, empty values are null, and optional settings the API
+// leaves out of its response are filled in with the defaults Marqo applies.
+func configFormIndexSettings(settings *IndexSettingsModel) {
+	normalizeIndexSettings(settings)
+
+	if len(settings.AllFields) == 0 {
+		settings.AllFields = nil
+	}
+	for i := range settings.AllFields {
+		if len(settings.AllFields[i].Features) == 0 {
+			settings.AllFields[i].Features = nil
+		}
+		if len(settings.AllFields[i].DependentFields) == 0 {
+			settings.AllFields[i].DependentFields = nil
 		}
+	}
+	if len(settings.TensorFields) == 0 {
+		settings.TensorFields = nil
+	}
 
-		// marqo doesn't return timeouts, so we maintain the existing state
-		newState.Timeouts = state.Timeouts
+	if settings.ImagePreprocessing != nil && settings.ImagePreprocessing.PatchMethod.ValueString() == "" {
+		settings.ImagePreprocessing = nil
+	}
 
-		// Special handling for import case - if this is a new import (state has empty values)
-		// we need to ensure consistent null values
-		if isImport {
-			// For import operations, we want to set all optional fields to null
-			// unless they have explicit non-default values
+	if settings.VectorNumericType.IsNull() {
+		settings.VectorNumericType = types.StringValue(defaultVectorNumericType)
+	}
+	if settings.NormalizeEmbeddings.IsNull() {
+		settings.NormalizeEmbeddings = types.BoolValue(defaultNormalizeEmbeddings)
+	}
+	if settings.TextPreprocessing == nil {
+		settings.TextPreprocessing = defaultTextPreprocessing()
+	}
+	if settings.AnnParameters == nil {
+		settings.AnnParameters = defaultAnnParameters()
+	}
 
-			// Handle optional scalar fields
-			if newState.Settings.FilterStringMaxLength.ValueInt64() == 0 {
-				newState.Settings.FilterStringMaxLength = types.Int64Null()
-			}
+	// These settings only apply to unstructured indexes
+	if settings.Type.ValueString() == "structured" {
+		settings.FilterStringMaxLength = types.Int64Null()
+		settings.TreatUrlsAndPointersAsImages = types.BoolNull()
+		settings.TreatUrlsAndPointersAsMedia = types.BoolNull()
+	}
+}
 
-			// Set empty string vector_numeric_type to null
-			if newState.Settings.VectorNumericType.ValueString() == "" {
-				newState.Settings.VectorNumericType = types.StringNull()
+// preserveConfigForm keeps the prior state's form for refreshed settings that agree with it:
+// optional settings left null stay null while the index still uses Marqo's default, and empty lists
+// and objects written in configuration stay empty rather than null. Any other value is taken from
+// the refreshed settings so that changes made outside of Terraform are detected.
+func preserveConfigForm(refreshed *IndexSettingsModel, prior IndexSettingsModel) {
+	if prior.AllFields != nil && len(prior.AllFields) == 0 && refreshed.AllFields == nil {
+		refreshed.AllFields = []AllFieldInput{}
+	}
+	for i := range refreshed.AllFields {
+		for _, priorField := range prior.AllFields {
+			if !priorField.Name.Equal(refreshed.AllFields[i].Name) {
+				continue
 			}
-
-			// Set empty objects to null
-			if newState.Settings.AllFields != nil && len(newState.Settings.AllFields) == 0 {
-				newState.Settings.AllFields = nil
+			if priorField.Features != nil && len(priorField.Features) == 0 && refreshed.AllFields[i].Features == nil {
+				refreshed.AllFields[i].Features = []types.String{}
 			}
-			if newState.Settings.TensorFields != nil && len(newState.Settings.TensorFields) == 0 {
-				newState.Settings.TensorFields = nil
+			if priorField.DependentFields != nil && len(priorField.DependentFields) == 0 && refreshed.AllFields[i].DependentFields == nil {
+				refreshed.AllFields[i].DependentFields = map[string]types.Float64{}
 			}
+		}
+	}
+	if prior.TensorFields != nil && len(prior.TensorFields) == 0 && refreshed.TensorFields == nil {
+		refreshed.TensorFields = []string{}
+	}
+	if refreshed.ImagePreprocessing == nil && prior.ImagePreprocessing != nil && prior.ImagePreprocessing.PatchMethod.ValueString() == "" {
+		refreshed.ImagePreprocessing = prior.ImagePreprocessing
+	}
 
-			// Handle optional nested objects
-			if newState.Settings.TextPreprocessing != nil &&
-				newState.Settings.TextPreprocessing.SplitLength.ValueInt64() == 0 &&
-				newState.Settings.TextPreprocessing.SplitMethod.ValueString() == "" &&
-				newState.Settings.TextPreprocessing.SplitOverlap.ValueInt64() == 0 {
-				newState.Settings.TextPreprocessing = nil
-			}
+	if prior.FilterStringMaxLength.IsNull() &&
+		(refreshed.FilterStringMaxLength.IsNull() || refreshed.FilterStringMaxLength.ValueInt64() == defaultFilterStringMaxLength) {
+		refreshed.FilterStringMaxLength = types.Int64Null()
+	}
+	if prior.TreatUrlsAndPointersAsImages.IsNull() &&
+		(refreshed.TreatUrlsAndPointersAsImages.IsNull() || refreshed.TreatUrlsAndPointersAsImages.ValueBool() == defaultTreatUrlsAndPointersAsImages) {
+		refreshed.TreatUrlsAndPointersAsImages = types.BoolNull()
+	}
+	if prior.TreatUrlsAndPointersAsMedia.IsNull() &&
+		(refreshed.TreatUrlsAndPointersAsMedia.IsNull() || refreshed.TreatUrlsAndPointersAsMedia.ValueBool() == defaultTreatUrlsAndPointersAsMedia) {
+		refreshed.TreatUrlsAndPointersAsMedia = types.BoolNull()
+	}
 
-			if state.Settings.ImagePreprocessing == nil {
-				newState.Settings.ImagePreprocessing = nil
-			} else {
-				newState.Settings.ImagePreprocessing = state.Settings.ImagePreprocessing
-			}
+	// video_preprocessing and audio_preprocessing are only reported for indexes whose model handles
+	// video or audio, so the prior values are kept when the index does not report them
+	if refreshed.VideoPreprocessing == nil {
+		refreshed.VideoPreprocessing = prior.VideoPreprocessing
+	}
+	if refreshed.AudioPreprocessing == nil {
+		refreshed.AudioPreprocessing = prior.AudioPreprocessing
+	}
+}
 
-			if newState.Settings.VideoPreprocessing != nil &&
-				newState.Settings.VideoPreprocessing.SplitLength.ValueInt64() == 0 &&
-				newState.Settings.VideoPreprocessing.SplitOverlap.ValueInt64() == 0 {
-				newState.Settings.VideoPreprocessing = nil
-			}
+// appendIfDifferent records a difference for an expected setting whose value does not match the existing index.
+// Settings that are null are skipped, since Marqo fills in defaults for them.
+func appendIfDifferent(diffs []string, name string, desired attr.Value, existing attr.Value) []string {
+	if desired.IsNull() || desired.IsUnknown() || desired.Equal(existing) {
+		return diffs
+	}
+	return append(diffs, fmt.Sprintf("  %s: expected %s, index has %s", name, desired, existing))
+}
 
-			if newState.Settings.AudioPreprocessing != nil &&
-				newState.Settings.AudioPreprocessing.SplitLength.ValueInt64() == 0 &&
-				newState.Settings.AudioPreprocessing.SplitOverlap.ValueInt64() == 0 {
-				newState.Settings.AudioPreprocessing = nil
-			}
+// indexSettingsDiff compares the desired settings, from the plan or the prior state, with an existing index
+// as built by findAndCreateState and normalized, and returns one line for every non-null setting that differs.
+func indexSettingsDiff(existing IndexSettingsModel, desired IndexSettingsModel) []string {
+	var diffs []string
+
+	diffs = appendIfDifferent(diffs, "type", desired.Type, existing.Type)
+	diffs = appendIfDifferent(diffs, "model", desired.Model, existing.Model)
+	diffs = appendIfDifferent(diffs, "inference_type", desired.InferenceType, existing.InferenceType)
+	diffs = appendIfDifferent(diffs, "number_of_inferences", desired.NumberOfInferences, existing.NumberOfInferences)
+	diffs = appendIfDifferent(diffs, "storage_class", desired.StorageClass, existing.StorageClass)
+	diffs = appendIfDifferent(diffs, "number_of_shards", desired.NumberOfShards, existing.NumberOfShards)
+	diffs = appendIfDifferent(diffs, "number_of_replicas", desired.NumberOfReplicas, existing.NumberOfReplicas)
+	diffs = appendIfDifferent(diffs, "vector_numeric_type", desired.VectorNumericType, existing.VectorNumericType)
+	diffs = appendIfDifferent(diffs, "normalize_embeddings", desired.NormalizeEmbeddings, existing.NormalizeEmbeddings)
+	diffs = appendIfDifferent(diffs, "treat_urls_and_pointers_as_images", desired.TreatUrlsAndPointersAsImages, existing.TreatUrlsAndPointersAsImages)
+	diffs = appendIfDifferent(diffs, "treat_urls_and_pointers_as_media", desired.TreatUrlsAndPointersAsMedia, existing.TreatUrlsAndPointersAsMedia)
+	diffs = appendIfDifferent(diffs, "filter_string_max_length", desired.FilterStringMaxLength, existing.FilterStringMaxLength)
+
+	if len(desired.TensorFields) > 0 && !reflect.DeepEqual(desired.TensorFields, existing.TensorFields) {
+		diffs = append(diffs, fmt.Sprintf("  tensor_fields: expected %v, index has %v", desired.TensorFields, existing.TensorFields))
+	}
+
+	if len(desired.AllFields) > 0 {
+		if changes := allFieldsChanges(desired.AllFields, existing.AllFields); len(changes) > 0 {
+			diffs = append(diffs, fmt.Sprintf("  all_fields: index has %s", strings.Join(changes, ", ")))
+		}
+	}
 
-			if newState.Settings.AnnParameters != nil &&
-				newState.Settings.AnnParameters.SpaceType.ValueString() == "" &&
-				newState.Settings.AnnParameters.Parameters != nil &&
-				newState.Settings.AnnParameters.Parameters.EfConstruction.ValueInt64() == 0 &&
-				newState.Settings.AnnParameters.Parameters.M.ValueInt64() == 0 {
-				newState.Settings.AnnParameters = nil
-			}
+	if desired.TextPreprocessing != nil {
+		existingText := existing.TextPreprocessing
+		if existingText == nil {
+			existingText = &TextPreprocessingModelCreate{}
+		}
+		diffs = appendIfDifferent(diffs, "text_preprocessing.split_length", desired.TextPreprocessing.SplitLength, existingText.SplitLength)
+		diffs = appendIfDifferent(diffs, "text_preprocessing.split_method", desired.TextPreprocessing.SplitMethod, existingText.SplitMethod)
+		diffs = appendIfDifferent(diffs, "text_preprocessing.split_overlap", desired.TextPreprocessing.SplitOverlap, existingText.SplitOverlap)
+	}
 
-			if newState.Settings.ModelProperties != nil && newState.Settings.ModelProperties.IsEmpty() {
-				newState.Settings.ModelProperties = nil
-			}
+	if desired.ImagePreprocessing != nil {
+		existingImage := existing.ImagePreprocessing
+		if existingImage == nil {
+			existingImage = &ImagePreprocessingModel{}
+		}
+		diffs = appendIfDifferent(diffs, "image_preprocessing.patch_method", desired.ImagePreprocessing.PatchMethod, existingImage.PatchMethod)
+	}
 
-			// preserve the video/audio preprocessing from current state since api does not return them
-			if state.Settings.VideoPreprocessing != nil {
-				newState.Settings.VideoPreprocessing = state.Settings.VideoPreprocessing
-			} else if newState.Settings.VideoPreprocessing != nil {
-				// If not in state but returned by API with zero values, set to null
-				// Doesnt do anything now because API doesn't return video/audio preprocessing
-				if newState.Settings.VideoPreprocessing.SplitLength.IsNull() &&
-					newState.Settings.VideoPreprocessing.SplitOverlap.IsNull() {
-					newState.Settings.VideoPreprocessing = nil
-				}
+	if desired.AnnParameters != nil {
+		existingAnn := existing.AnnParameters
+		if existingAnn == nil {
+			existingAnn = &AnnParametersModelCreate{}
+		}
+		diffs = appendIfDifferent(diffs, "ann_parameters.space_type", desired.AnnParameters.SpaceType, existingAnn.SpaceType)
+		if desired.AnnParameters.Parameters != nil {
+			existingParameters := existingAnn.Parameters
+			if existingParameters == nil {
+				existingParameters = &ParametersModel{}
 			}
+			diffs = appendIfDifferent(diffs, "ann_parameters.parameters.ef_construction", desired.AnnParameters.Parameters.EfConstruction, existingParameters.EfConstruction)
+			diffs = appendIfDifferent(diffs, "ann_parameters.parameters.m", desired.AnnParameters.Parameters.M, existingParameters.M)
+		}
+	}
 
-			if state.Settings.AudioPreprocessing != nil {
-				newState.Settings.AudioPreprocessing = state.Settings.AudioPreprocessing
-			} else if newState.Settings.AudioPreprocessing != nil {
-				// If not in state but returned by API with zero values, set to null
-				// Doesnt do anything now because API doesn't return video/audio preprocessing
-				if newState.Settings.AudioPreprocessing.SplitLength.IsNull() &&
-					newState.Settings.AudioPreprocessing.SplitOverlap.IsNull() {
-					newState.Settings.AudioPreprocessing = nil
-				}
-			}
-		} else {
-			// For non-import operations, preserve values from the existing state
-
-			// Handle AllFields
-			if state.Settings.AllFields == nil {
-				newState.Settings.AllFields = nil
-			} else if len(newState.Settings.AllFields) == 0 {
-				newState.Settings.AllFields = []AllFieldInput{}
-			} else {
-				// Ensure features and dependent_fields are always set
-				for i := range newState.Settings.AllFields {
-					if len(newState.Settings.AllFields[i].Features) == 0 {
-						newState.Settings.AllFields[i].Features = nil
-					}
-					if len(newState.Settings.AllFields[i].DependentFields) == 0 {
-						newState.Settings.AllFields[i].DependentFields = nil
-					}
-				}
-			}
+	if desired.ModelProperties != nil {
+		existingProperties := existing.ModelProperties
+		if existingProperties == nil {
+			existingProperties = &ModelPropertiesModelCreate{}
+		}
+		diffs = appendIfDifferent(diffs, "model_properties.name", desired.ModelProperties.Name, existingProperties.Name)
+		diffs = appendIfDifferent(diffs, "model_properties.dimensions", desired.ModelProperties.Dimensions, existingProperties.Dimensions)
+		diffs = appendIfDifferent(diffs, "model_properties.type", desired.ModelProperties.Type, existingProperties.Type)
+		diffs = appendIfDifferent(diffs, "model_properties.tokens", desired.ModelProperties.Tokens, existingProperties.Tokens)
+		diffs = appendIfDifferent(diffs, "model_properties.url", desired.ModelProperties.Url, existingProperties.Url)
+	}
 
-			// Handle TensorFields
-			if len(state.Settings.TensorFields) == 0 {
-				newState.Settings.TensorFields = nil
-			}
+	// video_preprocessing and audio_preprocessing are not returned by the API, so they cannot be compared.
 
-			// Handle optional nested objects
-			// If these fields are not set in the state, set them to null
-			if state.Settings.TextPreprocessing == nil {
-				newState.Settings.TextPreprocessing = nil
-			}
-			if state.Settings.AnnParameters == nil {
-				newState.Settings.AnnParameters = nil
-			}
+	return diffs
+}
 
-			// Handle optional scalar fields
-			// If these fields are null in the state, keep them null
-			if state.Settings.FilterStringMaxLength.IsNull() {
-				newState.Settings.FilterStringMaxLength = types.Int64Null()
-			}
+// existingIndexSettingsDifferDetail explains why an existing index cannot be adopted.
+func existingIndexSettingsDifferDetail(indexName string, diffs []string) string {
+	return fmt.Sprintf("Index %s already exists, but its settings do not match the configuration:\n\n%s\n\n"+
+		"Update the configuration to match the existing index, or delete the index and apply again.",
+		indexName, strings.Join(diffs, "\n"))
+}
 
-			// For boolean fields, if they're explicitly set in the configuration,
-			// use those values; otherwise keep them null
-			if !state.Settings.NormalizeEmbeddings.IsNull() {
-				newState.Settings.NormalizeEmbeddings = state.Settings.NormalizeEmbeddings
-			} else {
-				newState.Settings.NormalizeEmbeddings = types.BoolNull()
-			}
-			if !state.Settings.TreatUrlsAndPointersAsImages.IsNull() {
-				newState.Settings.TreatUrlsAndPointersAsImages = state.Settings.TreatUrlsAndPointersAsImages
-			} else {
-				newState.Settings.TreatUrlsAndPointersAsImages = types.BoolNull()
-			}
-			if !state.Settings.TreatUrlsAndPointersAsMedia.IsNull() {
-				newState.Settings.TreatUrlsAndPointersAsMedia = state.Settings.TreatUrlsAndPointersAsMedia
-			} else {
-				newState.Settings.TreatUrlsAndPointersAsMedia = types.BoolNull()
-			}
+// indexAlreadyExistsDetail explains how to manage an index that exists outside of Terraform state.
+func indexAlreadyExistsDetail(indexName string) string {
+	return fmt.Sprintf("Index %s already exists. Set adopt_existing = true to bring it under Terraform management, "+
+		"or import it with: terraform import <resource address> %s", indexName, indexName)
+}
 
-			if state.Settings.VectorNumericType.IsNull() {
-				newState.Settings.VectorNumericType = types.StringNull()
-			}
+// indexNotReadyDetail explains that an index cannot be updated until it is READY.
+func indexNotReadyDetail(indexName string, status string) string {
+	return fmt.Sprintf("Cannot update index %s: current status is %s, must be READY", indexName, status)
+}
 
-			if state.Settings.ImagePreprocessing == nil {
-				newState.Settings.ImagePreprocessing = nil
-			} else {
-				newState.Settings.ImagePreprocessing = state.Settings.ImagePreprocessing
-			}
+// scalingDecreaseDiagnostics reports planned shard or replica counts below those of the existing
+// index, since Marqo can only increase them.
+func scalingDecreaseDiagnostics(current *go_marqo.IndexDetail, settings IndexSettingsModel) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-			// preserve the video/audio preprocessing from current state since api does not return them
-			if state.Settings.VideoPreprocessing != nil {
-				newState.Settings.VideoPreprocessing = state.Settings.VideoPreprocessing
-			} else if newState.Settings.VideoPreprocessing != nil {
-				// If not in state but returned by API with zero values, set to null
-				if newState.Settings.VideoPreprocessing.SplitLength.ValueInt64() == 0 &&
-					newState.Settings.VideoPreprocessing.SplitOverlap.ValueInt64() == 0 {
-					newState.Settings.VideoPreprocessing = nil
-				}
-			}
+	if !settings.NumberOfShards.IsUnknown() && settings.NumberOfShards.ValueInt64() < current.NumberOfShards {
+		diags.AddAttributeError(
+			path.Root("settings").AtName("number_of_shards"),
+			"Invalid Shard Count",
+			fmt.Sprintf("Cannot decrease number of shards from %d to %d. Shards can only be increased.",
+				current.NumberOfShards, settings.NumberOfShards.ValueInt64()))
+	}
 
-			if state.Settings.AudioPreprocessing != nil {
-				newState.Settings.AudioPreprocessing = state.Settings.AudioPreprocessing
-			} else if newState.Settings.AudioPreprocessing != nil {
-				// If not in state but returned by API with zero values, set to null
-				if newState.Settings.AudioPreprocessing.SplitLength.ValueInt64() == 0 &&
-					newState.Settings.AudioPreprocessing.SplitOverlap.ValueInt64() == 0 {
-					newState.Settings.AudioPreprocessing = nil
-				}
-			}
+	if !settings.NumberOfReplicas.IsUnknown() && settings.NumberOfReplicas.ValueInt64() < current.NumberOfReplicas {
+		diags.AddAttributeError(
+			path.Root("settings").AtName("number_of_replicas"),
+			"Invalid Replica Count",
+			fmt.Sprintf("Cannot decrease number of replicas from %d to %d. Replicas can only be increased.",
+				current.NumberOfReplicas, settings.NumberOfReplicas.ValueInt64()))
+	}
+
+	return diags
+}
+
+// adoptExistingIndex looks for an index with the planned name and, if its settings are compatible
+// with the plan, writes it to state. It reports whether the index was found; on a mismatch the
+// differences are added to the diagnostics.
+func (r *indicesResource) adoptExistingIndex(ctx context.Context, model *IndexResourceModel, resp *resource.CreateResponse) bool {
+	indexName := model.IndexName.ValueString()
+
+	indices, err := r.marqoClient.ListIndices()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
+		return true
+	}
+
+	existingState, found := r.findAndCreateState(indices, indexName, model.Timeouts)
+	if !found {
+		return false
+	}
+	configFormIndexSettings(&existingState.Settings)
+	splitDiscoveredFields(existingState, model.Settings.AllFields)
+
+	if diffs := indexSettingsDiff(existingState.Settings, model.Settings); len(diffs) > 0 {
+		resp.Diagnostics.AddError("Existing Index Settings Differ", existingIndexSettingsDifferDetail(indexName, diffs))
+		return true
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Adopting existing index %s into Terraform state", indexName))
+
+	model.MarqoEndpoint = existingState.MarqoEndpoint
+	model.IndexStatus = existingState.IndexStatus
+	model.DiscoveredFields = existingState.DiscoveredFields
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ownedIndexKey, ownedIndexValue(indexName))...)
+	resp.Diagnostics.AddWarning(
+		fmt.Sprintf("Index %s already existed and has been imported into Terraform state.", indexName),
+		"The existing index settings match the configuration, so no changes were made to the index.")
+	return true
+}
+
+// ownedIndexKey is the private state key that records the index a marqo_index resource manages.
+// Terraform plans the create half of a replacement with a null prior state but passes the private
+// state on, so it tells the index being replaced apart from one that exists outside of state.
+const ownedIndexKey = "owned_index_name"
+
+// ownedIndexValue returns the private state value recording indexName as the managed index.
+func ownedIndexValue(indexName string) []byte {
+	value, _ := json.Marshal(indexName)
+	return value
+}
+
+// indexFailedError is returned by waitForIndexStatus when Marqo reports the index as FAILED.
+type indexFailedError struct {
+	indexName string
+	reason    string
+}
+
+func (e *indexFailedError) Error() string {
+	return fmt.Sprintf("index %s reached FAILED status: %s", e.indexName, e.reason)
+}
+
+// failureReason returns the reason Marqo reports for a FAILED index.
+func failureReason(indexName string, indices []go_marqo.IndexDetail) string {
+	for _, index := range indices {
+		if index.IndexName == indexName && index.FailureReason != "" {
+			return index.FailureReason
 		}
+	}
+	return "Marqo did not report a reason for the failure"
+}
 
-		// Ignore these fields for structured indexes
-		if newState.Settings.Type.ValueString() == "structured" {
-			newState.Settings.FilterStringMaxLength = types.Int64Null()
-			newState.Settings.TreatUrlsAndPointersAsImages = types.BoolNull()
-			newState.Settings.TreatUrlsAndPointersAsMedia = types.BoolNull()
-		}
+// isIndexStatusPending reports whether an index status is a transitional one that resolves to READY or FAILED.
+func isIndexStatusPending(status string) bool {
+	return status == "CREATING" || status == "MODIFYING"
+}
 
-		// Remove null fields
-		if newState.Settings.InferenceType.IsNull() {
-			newState.Settings.InferenceType = types.StringNull()
+// pendingStatusTimeout returns how long a refresh waits for a pending index: the create timeout for
+// an index being created and the update timeout for one being modified.
+func pendingStatusTimeout(ctx context.Context, state IndexResourceModel) time.Duration {
+	configured := ""
+	if state.Timeouts != nil {
+		configured = state.Timeouts.Create.ValueString()
+		if state.IndexStatus.ValueString() == "MODIFYING" {
+			configured = state.Timeouts.Update.ValueString()
 		}
 	}
-
-	// if index no longer exists in cloud, delete the state
-	if !found {
-		resp.Diagnostics.AddWarning("Resource Not Found", "The specified index does not exist in the cloud. The state will be deleted.")
-		// Then Totally Remove from terraform resources
-		resp.State.RemoveResource(ctx)
-		return
+	if configured == "" {
+		return 30 * time.Minute
 	}
 
-	// Set the updated state
-	diags = resp.State.Set(ctx, &newState)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	parsedTimeout, err := time.ParseDuration(configured)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Invalid timeout duration: %s, using default of 30m", err))
+		return 30 * time.Minute
 	}
+	return parsedTimeout
+}
+
+// indexUpdateNeeded reports whether an update changes the index itself. Every other setting requires
+// replacement, so an update only changes inference_type, number_of_inferences, number_of_shards and
+// number_of_replicas (which can only go up), or adds fields to a semi-structured index.
+func indexUpdateNeeded(state IndexResourceModel, plan IndexResourceModel) bool {
+	fieldsAdded := plan.Settings.Type.ValueString() == indexTypeSemiStructured &&
+		len(allFieldsChanges(state.Settings.AllFields, plan.Settings.AllFields)) > 0
+	return fieldsAdded || !plan.Settings.InferenceType.Equal(state.Settings.InferenceType) ||
+		!plan.Settings.NumberOfInferences.Equal(state.Settings.NumberOfInferences) ||
+		!plan.Settings.NumberOfShards.Equal(state.Settings.NumberOfShards) ||
+		!plan.Settings.NumberOfReplicas.Equal(state.Settings.NumberOfReplicas)
+}
+
+// waitForReady reports whether create and update should block until the index is READY, which is the default.
+func (m *IndexResourceModel) waitForReady() bool {
+	return m.WaitForReady.IsNull() || m.WaitForReady.IsUnknown() || m.WaitForReady.ValueBool()
+}
+
+// findIndexDetail returns the current details of a single index, or nil if it does not exist.
+func (r *indicesResource) findIndexDetail(indexName string) (*go_marqo.IndexDetail, error) {
+	return lookupIndex(r.marqoClient, indexName)
 }
 
-// Standalone function to compare states.
-func statesAreEqual(existing *IndexResourceModel, desired *IndexResourceModel) bool {
-	// A deep comparison between existing and desired states
-	return reflect.DeepEqual(existing.Settings, desired.Settings)
+// lookupIndex returns the current details of a single index, or nil if it does not exist.
+func lookupIndex(client *go_marqo.Client, indexName string) (*go_marqo.IndexDetail, error) {
+	indices, err := client.ListIndices()
+	if err != nil {
+		return nil, err
+	}
+	for i := range indices {
+		if indices[i].IndexName == indexName {
+			return &indices[i], nil
+		}
+	}
+	return nil, nil
 }
 
 // waitForIndexStatus waits for an index to reach a target status or be deleted.
@@ -890,8 +1411,8 @@ func (r *indicesResource) waitForIndexStatus(ctx context.Context, indexName stri
 
 		for _, index := range indices {
 			if index.IndexName == indexName {
-				if index.IndexStatus != "READY" && index.IndexStatus != "DELETING" {
-					return fmt.Errorf("cannot delete index %s: index is in %s state, must be in READY state",
+				if index.IndexStatus != "READY" && index.IndexStatus != "DELETING" && index.IndexStatus != "FAILED" {
+					return fmt.Errorf("cannot delete index %s: index is in %s state, must be in READY or FAILED state",
 						indexName, index.IndexStatus)
 				}
 				break
@@ -982,8 +1503,7 @@ func (r *indicesResource) waitForIndexStatus(ctx context.Context, indexName stri
 							indexName, targetStatus, time.Since(start)))
 						return nil
 					} else if index.IndexStatus == "FAILED" {
-						return fmt.Errorf("index %s reached FAILED status while waiting for %s",
-							indexName, targetStatus)
+						return &indexFailedError{indexName: indexName, reason: failureReason(indexName, indices)}
 					}
 					break
 				}
@@ -992,6 +1512,27 @@ func (r *indicesResource) waitForIndexStatus(ctx context.Context, indexName stri
 	}
 }
 
+// setAcceptedState records the planned settings together with the status Marqo currently reports,
+// for create and update requests that do not wait for the index to become READY.
+func (r *indicesResource) setAcceptedState(ctx context.Context, model *IndexResourceModel, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	indexName := model.IndexName.ValueString()
+
+	indexDetail, err := r.findIndexDetail(indexName)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not look up status of index %s: %s", indexName, err))
+	} else if indexDetail != nil {
+		model.IndexStatus = types.StringValue(indexDetail.IndexStatus)
+		if indexDetail.MarqoEndpoint != "" {
+			model.MarqoEndpoint = types.StringValue(indexDetail.MarqoEndpoint)
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Not waiting for index %s to become READY (status: %s)", indexName, model.IndexStatus.ValueString()))
+
+	diags := state.Set(ctx, model)
+	diagnostics.Append(diags...)
+}
+
 func (r *indicesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var model IndexResourceModel
 	diags := req.Plan.Get(ctx, &model)
@@ -1000,6 +1541,23 @@ func (r *indicesResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	if model.IndexName.IsUnknown() || model.IndexName.IsNull() {
+		indexName, err := generateIndexName(model.NamePrefix.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to Generate Index Name", err.Error())
+			return
+		}
+		model.IndexName = types.StringValue(indexName)
+	}
+	model.DiscoveredFields = noDiscoveredFields()
+
+	release, err := r.limiter.acquire(ctx, "create", model.IndexName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Queue Index Operation", err.Error())
+		return
+	}
+	defer release()
+
 	// Construct settings map
 	settings := map[string]interface{}{
 		"type":                  model.Settings.Type.ValueString(),
@@ -1147,10 +1705,9 @@ func (r *indicesResource) Create(ctx context.Context, req resource.CreateRequest
 		delete(settings, "filterStringMaxLength")
 	}
 
-	tflog.Debug(ctx, "Creating index with settings: %#v", settings)
-
-	// Adjust settings for structured index
-	if model.Settings.Type.ValueString() == "structured" {
+	// Adjust settings for structured index, and semi-structured indexes created with declared fields
+	if model.Settings.Type.ValueString() == "structured" ||
+		(model.Settings.Type.ValueString() == indexTypeSemiStructured && len(model.Settings.AllFields) > 0) {
 		allFields, err := validateAndConstructAllFields(model.Settings.AllFields)
 		if err != nil {
 			resp.Diagnostics.AddError("Invalid allFields", "Error validating allFields: "+err.Error())
@@ -1168,6 +1725,19 @@ func (r *indicesResource) Create(ctx context.Context, req resource.CreateRequest
 		//}
 	}
 
+	// Settings the provider does not support are merged in last. ValidateConfig has checked that they
+	// leave the settings above alone.
+	if !model.Settings.ExtraSettingsJSON.IsNull() {
+		extra, err := parseExtraSettings(model.Settings.ExtraSettingsJSON.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Extra Settings JSON", err.Error())
+			return
+		}
+		mergeSettings(settings, extra)
+	}
+
+	tflog.Debug(ctx, "Creating index with settings", map[string]any{"settings": settings})
+
 	// Parse timeout duration
 	timeoutDuration := 30 * time.Minute // default timeout
 	if model.Timeouts != nil && model.Timeouts.Create.ValueString() != "" {
@@ -1184,56 +1754,55 @@ func (r *indicesResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	indexName := model.IndexName.ValueString()
-	err := r.marqoClient.CreateIndex(indexName, settings)
-	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			tflog.Info(ctx, fmt.Sprintf("Index %s already exists. Checking if it needs to be updated.", indexName))
 
-			indices, err := r.marqoClient.ListIndices()
-			if err != nil {
-				resp.Diagnostics.AddError("Failed to List Indices", fmt.Sprintf("Could not list indices: %s", err.Error()))
-				return
-			}
+	// Adopt the index up front if it already exists, rather than relying on the create error
+	if model.AdoptExisting.ValueBool() && r.adoptExistingIndex(ctx, &model, resp) {
+		return
+	}
 
-			existingState, found := r.findAndCreateState(indices, indexName, model.Timeouts)
-			if !found {
-				resp.Diagnostics.AddError("Failed to Find Index", fmt.Sprintf("Index %s not found after creation", indexName))
+	err = r.marqoClient.CreateIndex(indexName, settings)
+	if err != nil {
+		if errors.Is(err, go_marqo.ErrIndexAlreadyExists) {
+			// The index may have been created between the lookup above and the create request
+			if model.AdoptExisting.ValueBool() && r.adoptExistingIndex(ctx, &model, resp) {
 				return
 			}
 
-			// Compare existing state with desired state
-			if !statesAreEqual(existingState, &model) {
-				// Attempt to update the existing index
-				err = r.marqoClient.UpdateIndex(indexName, settings)
-				if err != nil {
-					resp.Diagnostics.AddError("Failed to Update Existing Index",
-						fmt.Sprintf("Index %s exists but couldn't be updated to match the configuration: %s", indexName, err.Error()))
-					return
-				}
-				tflog.Info(ctx, fmt.Sprintf("Index %s updated to match configuration.", indexName))
-			} else {
-				tflog.Info(ctx, fmt.Sprintf("Existing index %s matches configuration. No update needed.", indexName))
-			}
-
-			// Set state to the (potentially updated) existing index with preserved null values
-			diags = resp.State.Set(ctx, existingState)
-			resp.Diagnostics.Append(diags...)
-			resp.Diagnostics.AddWarning(fmt.Sprintf("Index %s already existed and has been imported into Terraform state.", indexName),
-				"Any differences between the existing index and your configuration have been resolved by updating the index.")
+			resp.Diagnostics.AddError("Index Already Exists", indexAlreadyExistsDetail(indexName))
 			return
 		}
 
 		resp.Diagnostics.AddError("Failed to Create Index", "Could not create index: "+err.Error())
 		return
 	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ownedIndexKey, ownedIndexValue(indexName))...)
 
 	// Set initial state
 	model.MarqoEndpoint = types.StringValue("pending")
+	model.IndexStatus = types.StringValue("CREATING")
 	diags = resp.State.Set(ctx, &model)
 	resp.Diagnostics.Append(diags...)
 
+	if !model.waitForReady() {
+		r.setAcceptedState(ctx, &model, &resp.State, &resp.Diagnostics)
+		return
+	}
+
 	// Wait for the index to be ready
 	err = r.waitForIndexStatus(ctx, indexName, "READY", timeoutDuration, false)
+	var failedErr *indexFailedError
+	if errors.As(err, &failedErr) {
+		// Keep the failed index in state so Terraform taints it and replaces it on the next apply
+		model.IndexStatus = types.StringValue("FAILED")
+		diags = resp.State.Set(ctx, &model)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.AddError(
+			"Index Creation Failed",
+			fmt.Sprintf("Index %s reached FAILED status: %s\n\n"+
+				"The resource has been marked as tainted and will be deleted and recreated on the next apply.",
+				indexName, failedErr.reason))
+		return
+	}
 	if err != nil {
 		// If waiting failed, attempt to clean up the index
 		deleteErr := r.marqoClient.DeleteIndex(indexName)
@@ -1253,6 +1822,11 @@ func (r *indicesResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	// Record the READY status so that the final read does not wait for the index again
+	model.IndexStatus = types.StringValue("READY")
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+
 	// Do final read to get the complete state
 	readResp := resource.ReadResponse{State: resp.State}
 	r.Read(ctx, resource.ReadRequest{State: resp.State}, &readResp)
@@ -1273,6 +1847,13 @@ func (r *indicesResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	release, err := r.limiter.acquire(ctx, "delete", model.IndexName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Queue Index Operation", err.Error())
+		return
+	}
+	defer release()
+
 	indexName := model.IndexName.ValueString()
 
 	// Default timeout of 15 minutes for deletion
@@ -1288,7 +1869,7 @@ func (r *indicesResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	// Attempt to delete the index
-	err := r.marqoClient.DeleteIndex(indexName)
+	err = r.marqoClient.DeleteIndex(indexName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to Delete Index",
@@ -1324,136 +1905,26 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Validate that only allowed fields are being modified
-	// The only modifiable fields are:
-	// - inference_type
-	// - number_of_inferences
-	// - number_of_replicas (can only go up)
-	// - number_of_shards (can only go up)
-
-	// Check for changes in non-modifiable fields
-	if model.Settings.Type.ValueString() != state.Settings.Type.ValueString() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Index Type",
-			fmt.Sprintf("The index type cannot be modified from '%s' to '%s'. You must destroy and recreate the index to change this field.",
-				state.Settings.Type.ValueString(), model.Settings.Type.ValueString()))
-		return
-	}
-
-	if model.Settings.Model.ValueString() != state.Settings.Model.ValueString() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Index Model",
-			fmt.Sprintf("The model cannot be modified from '%s' to '%s'. You must destroy and recreate the index to change this field.",
-				state.Settings.Model.ValueString(), model.Settings.Model.ValueString()))
-		return
-	}
-
-	if model.Settings.StorageClass.ValueString() != state.Settings.StorageClass.ValueString() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Storage Class",
-			fmt.Sprintf("The storage class cannot be modified from '%s' to '%s'. You must destroy and recreate the index to change this field.",
-				state.Settings.StorageClass.ValueString(), model.Settings.StorageClass.ValueString()))
-		return
-	}
-
-	// Check for changes in other fields that are not modifiable
-	// This is not an exhaustive list, but covers the most common fields
-	if !reflect.DeepEqual(model.Settings.AllFields, state.Settings.AllFields) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify All Fields",
-			"The all_fields configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	if !reflect.DeepEqual(model.Settings.TensorFields, state.Settings.TensorFields) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Tensor Fields",
-			"The tensor_fields configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	// Check for changes in nested objects
-	if !reflect.DeepEqual(model.Settings.TextPreprocessing, state.Settings.TextPreprocessing) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Text Preprocessing",
-			"The text_preprocessing configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	if !reflect.DeepEqual(model.Settings.ImagePreprocessing, state.Settings.ImagePreprocessing) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Image Preprocessing",
-			"The image_preprocessing configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	if !reflect.DeepEqual(model.Settings.VideoPreprocessing, state.Settings.VideoPreprocessing) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Video Preprocessing",
-			"The video_preprocessing configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	if !reflect.DeepEqual(model.Settings.AudioPreprocessing, state.Settings.AudioPreprocessing) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Audio Preprocessing",
-			"The audio_preprocessing configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	if !reflect.DeepEqual(model.Settings.AnnParameters, state.Settings.AnnParameters) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify ANN Parameters",
-			"The ann_parameters configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	if !reflect.DeepEqual(model.Settings.ModelProperties, state.Settings.ModelProperties) {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Model Properties",
-			"The model_properties configuration cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	// Check for changes in scalar fields
-	if !model.Settings.VectorNumericType.IsNull() && !state.Settings.VectorNumericType.IsNull() &&
-		model.Settings.VectorNumericType.ValueString() != state.Settings.VectorNumericType.ValueString() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Vector Numeric Type",
-			"The vector_numeric_type field cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
-
-	if !model.Settings.FilterStringMaxLength.IsNull() && !state.Settings.FilterStringMaxLength.IsNull() &&
-		model.Settings.FilterStringMaxLength.ValueInt64() != state.Settings.FilterStringMaxLength.ValueInt64() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify Filter String Max Length",
-			"The filter_string_max_length field cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
+	// discovered_fields is refreshed by the final read
+	model.DiscoveredFields = state.DiscoveredFields
 
-	// Check for changes in boolean fields
-	if !model.Settings.TreatUrlsAndPointersAsImages.IsNull() && !state.Settings.TreatUrlsAndPointersAsImages.IsNull() &&
-		model.Settings.TreatUrlsAndPointersAsImages.ValueBool() != state.Settings.TreatUrlsAndPointersAsImages.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify treat_urls_and_pointers_as_images",
-			"This field cannot be modified. You must destroy and recreate the index to change this field.")
+	release, err := r.limiter.acquire(ctx, "update", model.IndexName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Queue Index Operation", err.Error())
 		return
 	}
+	defer release()
 
-	if !model.Settings.TreatUrlsAndPointersAsMedia.IsNull() && !state.Settings.TreatUrlsAndPointersAsMedia.IsNull() &&
-		model.Settings.TreatUrlsAndPointersAsMedia.ValueBool() != state.Settings.TreatUrlsAndPointersAsMedia.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify treat_urls_and_pointers_as_media",
-			"This field cannot be modified. You must destroy and recreate the index to change this field.")
-		return
-	}
+	fieldsAdded := model.Settings.Type.ValueString() == indexTypeSemiStructured &&
+		len(allFieldsChanges(state.Settings.AllFields, model.Settings.AllFields)) > 0
 
-	if !model.Settings.NormalizeEmbeddings.IsNull() && !state.Settings.NormalizeEmbeddings.IsNull() &&
-		model.Settings.NormalizeEmbeddings.ValueBool() != state.Settings.NormalizeEmbeddings.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Cannot Modify normalize_embeddings",
-			"This field cannot be modified. You must destroy and recreate the index to change this field.")
+	// Other changes, such as to timeouts or to the formatting of extra_settings_json, leave the index as it is
+	if !indexUpdateNeeded(state, model) {
+		tflog.Info(ctx, fmt.Sprintf("No settings of index %s changed, not updating the index", model.IndexName.ValueString()))
+		model.MarqoEndpoint = state.MarqoEndpoint
+		model.IndexStatus = state.IndexStatus
+		diags = resp.State.Set(ctx, &model)
+		resp.Diagnostics.Append(diags...)
 		return
 	}
 
@@ -1498,31 +1969,17 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	if currentStatus != "READY" {
-		resp.Diagnostics.AddError(
-			"Index Not Ready",
-			fmt.Sprintf("Cannot update index %s: current status is %s, must be READY", indexName, currentStatus))
+		resp.Diagnostics.AddError("Index Not Ready", indexNotReadyDetail(indexName, currentStatus))
 		return
 	}
 
 	// Add detailed logging for storage class
 	tflog.Debug(ctx, fmt.Sprintf("Storage class from current index: '%s'", currentIndex.StorageClass))
 
-	// Map storage class values similar to inference type
-	storageClassMap := map[string]string{
-		"BASIC":             "marqo.basic",
-		"BALANCED":          "marqo.balanced",
-		"PERFORMANCE":       "marqo.performance",
-		"marqo.basic":       "marqo.basic",
-		"marqo.balanced":    "marqo.balanced",
-		"marqo.performance": "marqo.performance",
-	}
-
 	mappedStorageClass := currentIndex.StorageClass
 	if mapped, exists := storageClassMap[currentIndex.StorageClass]; exists {
 		mappedStorageClass = mapped
 		tflog.Debug(ctx, fmt.Sprintf("Mapped storage class from '%s' to '%s'", currentIndex.StorageClass, mappedStorageClass))
-	} else {
-		tflog.Warn(ctx, fmt.Sprintf("No mapping found for storage class '%s'", currentIndex.StorageClass))
 	}
 
 	// Construct settings map with only the modifiable settings
@@ -1550,64 +2007,11 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 		model.Settings.NumberOfReplicas.ValueInt64()))
 
 	// Validate that shards and replicas are only increasing
-	if model.Settings.NumberOfShards.ValueInt64() < currentIndex.NumberOfShards {
-		resp.Diagnostics.AddError(
-			"Invalid Shard Count",
-			fmt.Sprintf("Cannot decrease number of shards from %d to %d. Shards can only be increased.",
-				currentIndex.NumberOfShards, model.Settings.NumberOfShards.ValueInt64()))
-		return
-	}
-
-	if model.Settings.NumberOfReplicas.ValueInt64() < currentIndex.NumberOfReplicas {
-		resp.Diagnostics.AddError(
-			"Invalid Replica Count",
-			fmt.Sprintf("Cannot decrease number of replicas from %d to %d. Replicas can only be increased.",
-				currentIndex.NumberOfReplicas, model.Settings.NumberOfReplicas.ValueInt64()))
-		return
-	}
-
-	// Check for drift between configuration and actual infrastructure
-	// Instead of silently using API values, detect drift and provide clear error messages
-	if currentIndex.NumberOfShards > model.Settings.NumberOfShards.ValueInt64() {
-		resp.Diagnostics.AddError(
-			"Drift Detected: Shard Count",
-			fmt.Sprintf("The API reports %d shards, but your configuration specifies %d shards.\n\n"+
-				"Shards can only be increased, not decreased. To resolve this:\n"+
-				"1. Update your configuration to match the current state: number_of_shards = %d\n"+
-				"2. Run terraform apply again\n\n"+
-				"This ensures your Terraform configuration accurately reflects the actual infrastructure.",
-				currentIndex.NumberOfShards, model.Settings.NumberOfShards.ValueInt64(), currentIndex.NumberOfShards))
-		return
-	}
-
-	if currentIndex.NumberOfReplicas > model.Settings.NumberOfReplicas.ValueInt64() {
-		resp.Diagnostics.AddError(
-			"Drift Detected: Replica Count",
-			fmt.Sprintf("The API reports %d replicas, but your configuration specifies %d replicas.\n\n"+
-				"Replicas can only be increased, not decreased. To resolve this:\n"+
-				"1. Update your configuration to match the current state: number_of_replicas = %d\n"+
-				"2. Run terraform apply again\n\n"+
-				"This ensures your Terraform configuration accurately reflects the actual infrastructure.",
-				currentIndex.NumberOfReplicas, model.Settings.NumberOfReplicas.ValueInt64(), currentIndex.NumberOfReplicas))
+	resp.Diagnostics.Append(scalingDecreaseDiagnostics(currentIndex, model.Settings)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Check if the number of inferences has been changed outside of Terraform
-	if !model.Settings.NumberOfInferences.IsNull() &&
-		currentIndex.NumberOfInferences != model.Settings.NumberOfInferences.ValueInt64() {
-		tflog.Info(ctx, fmt.Sprintf("API reports different number of inferences (%d) than plan (%d), will update to plan value",
-			currentIndex.NumberOfInferences, model.Settings.NumberOfInferences.ValueInt64()))
-	}
-
-	// Check if the inference type has been changed outside of Terraform
-	if !model.Settings.InferenceType.IsNull() &&
-		currentIndex.InferenceType != model.Settings.InferenceType.ValueString() &&
-		!strings.Contains(model.Settings.InferenceType.ValueString(), currentIndex.InferenceType) &&
-		!strings.Contains(currentIndex.InferenceType, model.Settings.InferenceType.ValueString()) {
-		tflog.Info(ctx, fmt.Sprintf("API reports different inference type (%s) than plan (%s), will update to plan value",
-			currentIndex.InferenceType, model.Settings.InferenceType.ValueString()))
-	}
-
 	if model.Settings.InferenceType.IsNull() {
 		delete(settings, "inferenceType")
 	}
@@ -1623,6 +2027,16 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 		delete(settings, "numberOfReplicas")
 	}
 
+	// Fields added to a semi-structured index are sent with the fields it already declares
+	if fieldsAdded {
+		allFields, err := validateAndConstructAllFields(model.Settings.AllFields)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid allFields", "Error validating allFields: "+err.Error())
+			return
+		}
+		settings["allFields"] = allFields
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Final update settings being sent: %+v", settings))
 
 	// Default timeout of 30 minutes for update
@@ -1638,6 +2052,7 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Attempt to update the index
+	var failedErr *indexFailedError
 	err = r.marqoClient.UpdateIndex(indexName, settings)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -1647,8 +2062,22 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	if !model.waitForReady() {
+		model.MarqoEndpoint = state.MarqoEndpoint
+		model.IndexStatus = types.StringValue("MODIFYING")
+		r.setAcceptedState(ctx, &model, &resp.State, &resp.Diagnostics)
+		return
+	}
+
 	// Wait for the index to be ready
 	err = r.waitForIndexStatus(ctx, indexName, "READY", timeoutDuration, false)
+	if errors.As(err, &failedErr) {
+		resp.Diagnostics.AddError(
+			"Index Update Failed",
+			fmt.Sprintf("Index %s reached FAILED status: %s\n\n"+
+				"The index will be planned for replacement on the next plan.", indexName, failedErr.reason))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Timeout Waiting for Index Update",
@@ -1660,6 +2089,10 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 	// Preserve computed/meta fields from current state
 	model.MarqoEndpoint = state.MarqoEndpoint
 	model.Timeouts = state.Timeouts
+	// Record the READY status so that the final read does not wait for the index again
+	model.IndexStatus = types.StringValue("READY")
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
 	readResp := resource.ReadResponse{State: resp.State}
 	r.Read(ctx, resource.ReadRequest{State: resp.State}, &readResp)
 	if readResp.Diagnostics.HasError() {
@@ -1673,7 +2106,143 @@ func (r *indicesResource) Update(ctx context.Context, req resource.UpdateRequest
 	resp.State = readResp.State
 }
 
-// ImportState imports an existing index into Terraform state.
+// ModifyPlan checks the plan against the live index so that conflicts are reported at plan time:
+// a new index whose name is already taken, shard or replica decreases, and updates to an index
+// that is not READY. Scaling changes are summarized with duration and cost estimates, and indexes
+// that Marqo reports as FAILED are planned for replacement.
+func (r *indicesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.planAutoscaling(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		r.modifyCreatePlan(ctx, req, resp)
+		return
+	}
+
+	var indexStatus types.String
+	diags := req.State.GetAttribute(ctx, path.Root("index_status"), &indexStatus)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if indexStatus.ValueString() == "FAILED" {
+		// A FAILED index cannot be repaired in place, so replace it
+		diags = resp.Plan.SetAttribute(ctx, path.Root("index_status"), types.StringUnknown())
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("index_status"))
+		return
+	}
+
+	// Only updates need to be checked against the live index
+	if resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	// index_status keeps its prior value unless the update changes the index, which leaves it
+	// MODIFYING, or READY once wait_for_ready has waited for it
+	var plan IndexResourceModel
+	var state IndexResourceModel
+	planDiags := resp.Plan.Get(ctx, &plan)
+	stateDiags := req.State.Get(ctx, &state)
+	if planDiags.HasError() || stateDiags.HasError() || indexUpdateNeeded(state, plan) {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("index_status"), types.StringUnknown())
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Plans with settings that are not yet known are checked again at apply time
+	if planDiags.HasError() || plan.IndexName.IsUnknown() || r.marqoClient == nil {
+		return
+	}
+
+	indexName := plan.IndexName.ValueString()
+	current, err := r.findIndexDetail(indexName)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to Check Index",
+			fmt.Sprintf("Could not check index %s against the plan: %s", indexName, err))
+		return
+	}
+	if current == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(scalingDecreaseDiagnostics(current, plan.Settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !stateDiags.HasError() {
+		if summary := scalingOperationSummary(current, state.Settings, plan.Settings, r.pricing); summary != "" {
+			resp.Diagnostics.AddWarning("Index Scaling Operation Planned", summary)
+		}
+	}
+
+	if current.IndexStatus != "READY" {
+		resp.Diagnostics.AddWarning("Index Not Ready",
+			indexNotReadyDetail(indexName, current.IndexStatus)+
+				". The apply will fail unless the index becomes READY before it starts.")
+	}
+}
+
+// modifyCreatePlan reports at plan time that a new index's name is already taken outside of state,
+// or, with adopt_existing, that the existing index cannot be adopted. The index a replacement deletes
+// first is recognised from private state and not reported.
+func (r *indicesResource) modifyCreatePlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.marqoClient == nil {
+		return
+	}
+
+	// Plans with settings that are not yet known are checked again at apply time
+	var plan IndexResourceModel
+	if diags := resp.Plan.Get(ctx, &plan); diags.HasError() || plan.IndexName.IsUnknown() {
+		return
+	}
+
+	indexName := plan.IndexName.ValueString()
+
+	// The index being replaced still exists when the replacement is planned
+	owned, diags := req.Private.GetKey(ctx, ownedIndexKey)
+	if !diags.HasError() && bytes.Equal(owned, ownedIndexValue(indexName)) {
+		tflog.Debug(ctx, fmt.Sprintf("Index %s is being replaced, not checking whether its name is taken", indexName))
+		return
+	}
+
+	indices, err := r.marqoClient.ListIndices()
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to Check Index",
+			fmt.Sprintf("Could not check whether index %s already exists: %s", indexName, err))
+		return
+	}
+
+	existing, found := r.findAndCreateState(indices, indexName, nil)
+	if !found {
+		return
+	}
+
+	if !plan.AdoptExisting.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("index_name"), "Index Already Exists", indexAlreadyExistsDetail(indexName))
+		return
+	}
+
+	configFormIndexSettings(&existing.Settings)
+	splitDiscoveredFields(existing, plan.Settings.AllFields)
+	if diffs := indexSettingsDiff(existing.Settings, plan.Settings); len(diffs) > 0 {
+		resp.Diagnostics.AddError("Existing Index Settings Differ", existingIndexSettingsDifferDetail(indexName, diffs))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Index %s already exists and will be adopted on apply", indexName))
+}
+
+// ImportState imports an existing index into Terraform state, with every setting written
+// the way it would appear in configuration so that the first plan after import is empty.
 func (r *indicesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Extract the index name from the import ID
 	indexName := req.ID
@@ -1690,16 +2259,8 @@ func (r *indicesResource) ImportState(ctx context.Context, req resource.ImportSt
 		return
 	}
 
-	// Find the index in the list
-	var foundIndex bool
-	for _, index := range indices {
-		if index.IndexName == indexName {
-			foundIndex = true
-			break
-		}
-	}
-
-	if !foundIndex {
+	importedState, found := r.findAndCreateState(indices, indexName, nil)
+	if !found {
 		resp.Diagnostics.AddError(
 			"Index Not Found",
 			fmt.Sprintf("Index with name %s was not found", indexName),
@@ -1707,27 +2268,10 @@ func (r *indicesResource) ImportState(ctx context.Context, req resource.ImportSt
 		return
 	}
 
-	// Create a minimal state with the index name and empty settings
-	// The Read method will be called after this to populate the full state
-	initialState := IndexResourceModel{
-		IndexName: types.StringValue(indexName),
-		Settings: IndexSettingsModel{
-			Type:               types.StringValue(""),
-			InferenceType:      types.StringValue(""),
-			NumberOfInferences: types.Int64Value(0),
-			StorageClass:       types.StringValue(""),
-			NumberOfShards:     types.Int64Value(0),
-			NumberOfReplicas:   types.Int64Value(0),
-			Model:              types.StringValue(""),
-			// Set boolean fields to null by default
-			NormalizeEmbeddings:          types.BoolNull(),
-			TreatUrlsAndPointersAsImages: types.BoolNull(),
-			TreatUrlsAndPointersAsMedia:  types.BoolNull(),
-			// Other fields will be populated by the Read method
-		},
-	}
+	configFormIndexSettings(&importedState.Settings)
+	// The fields of an imported semi-structured index are all treated as discovered
+	splitDiscoveredFields(importedState, nil)
 
-	// Set the entire state at once
-	diags := resp.State.Set(ctx, &initialState)
+	diags := resp.State.Set(ctx, importedState)
 	resp.Diagnostics.Append(diags...)
 }
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// AutoscalingModel maps the autoscaling block of marqo_index.
+type AutoscalingModel struct {
+	MinShards               types.Int64   `tfsdk:"min_shards"`
+	MaxShards               types.Int64   `tfsdk:"max_shards"`
+	MinReplicas             types.Int64   `tfsdk:"min_replicas"`
+	MaxReplicas             types.Int64   `tfsdk:"max_replicas"`
+	MemoryThresholdPercent  types.Float64 `tfsdk:"memory_threshold_percent"`
+	StorageThresholdPercent types.Float64 `tfsdk:"storage_threshold_percent"`
+}
+
+// autoscalingSchema returns the schema of the autoscaling block.
+func autoscalingSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Description: "Scale the index on each plan from its memory and storage usage. Autoscaling only manages " +
+			"number_of_shards and number_of_replicas when they are not set in the settings. When usage reaches a " +
+			"threshold, a shard is added until max_shards is reached, and then a replica until max_replicas is reached. " +
+			"Shards and replicas are never removed.",
+		Attributes: map[string]schema.Attribute{
+			"min_shards": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of shards a new index is created with, and the lowest number planned. Default is 1.",
+			},
+			"max_shards": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The highest number of shards autoscaling plans. Default is min_shards.",
+			},
+			"min_replicas": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of replicas a new index is created with, and the lowest number planned. Default is 0.",
+			},
+			"max_replicas": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The highest number of replicas autoscaling plans. Default is min_replicas.",
+			},
+			"memory_threshold_percent": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Scale up when the index memory usage reaches this percentage. Memory usage is ignored when not set.",
+			},
+			"storage_threshold_percent": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Scale up when the index storage usage reaches this percentage. Storage usage is ignored when not set.",
+			},
+		},
+	}
+}
+
+// limits returns the shard and replica bounds with defaults applied.
+func (a *AutoscalingModel) limits() (minShards, maxShards, minReplicas, maxReplicas int64) {
+	minShards, minReplicas = 1, 0
+	if !a.MinShards.IsNull() {
+		minShards = a.MinShards.ValueInt64()
+	}
+	if !a.MinReplicas.IsNull() {
+		minReplicas = a.MinReplicas.ValueInt64()
+	}
+	maxShards, maxReplicas = minShards, minReplicas
+	if !a.MaxShards.IsNull() {
+		maxShards = a.MaxShards.ValueInt64()
+	}
+	if !a.MaxReplicas.IsNull() {
+		maxReplicas = a.MaxReplicas.ValueInt64()
+	}
+	return minShards, maxShards, minReplicas, maxReplicas
+}
+
+// validateAutoscalingConfig requires shard and replica counts unless autoscaling manages them, and
+// checks that the autoscaling bounds are consistent.
+func validateAutoscalingConfig(config IndexResourceModel, diagnostics *diag.Diagnostics) {
+	if config.Autoscaling == nil {
+		for name, value := range map[string]types.Int64{
+			"number_of_shards":   config.Settings.NumberOfShards,
+			"number_of_replicas": config.Settings.NumberOfReplicas,
+		} {
+			if value.IsNull() {
+				diagnostics.AddAttributeError(
+					path.Root("settings").AtName(name),
+					"Missing Attribute Configuration",
+					fmt.Sprintf("settings.%s must be set unless an autoscaling block manages it.", name))
+			}
+		}
+		return
+	}
+
+	autoscaling := config.Autoscaling
+	for _, value := range []types.Int64{autoscaling.MinShards, autoscaling.MaxShards, autoscaling.MinReplicas, autoscaling.MaxReplicas} {
+		if value.IsUnknown() {
+			return
+		}
+	}
+
+	minShards, maxShards, minReplicas, maxReplicas := autoscaling.limits()
+	if minShards < 1 {
+		diagnostics.AddAttributeError(path.Root("autoscaling").AtName("min_shards"),
+			"Invalid Autoscaling Configuration", "min_shards must be at least 1.")
+	}
+	if minReplicas < 0 {
+		diagnostics.AddAttributeError(path.Root("autoscaling").AtName("min_replicas"),
+			"Invalid Autoscaling Configuration", "min_replicas must be at least 0.")
+	}
+	if maxShards < minShards {
+		diagnostics.AddAttributeError(path.Root("autoscaling").AtName("max_shards"),
+			"Invalid Autoscaling Configuration", fmt.Sprintf("max_shards (%d) must not be less than min_shards (%d).", maxShards, minShards))
+	}
+	if maxReplicas < minReplicas {
+		diagnostics.AddAttributeError(path.Root("autoscaling").AtName("max_replicas"),
+			"Invalid Autoscaling Configuration", fmt.Sprintf("max_replicas (%d) must not be less than min_replicas (%d).", maxReplicas, minReplicas))
+	}
+}
+
+// planAutoscaling plans the shard and replica counts that are left out of the configuration. New
+// indexes start at the autoscaling minimums. Existing indexes keep their counts, raised to the
+// minimums, and grow by one step when memory or storage usage reaches a threshold.
+func (r *indicesResource) planAutoscaling(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var config IndexResourceModel
+	if diags := req.Config.Get(ctx, &config); diags.HasError() {
+		return
+	}
+
+	// ValidateConfig requires the counts to be configured without autoscaling
+	shardsManaged := config.Settings.NumberOfShards.IsNull()
+	replicasManaged := config.Settings.NumberOfReplicas.IsNull()
+	if config.Autoscaling == nil || (!shardsManaged && !replicasManaged) {
+		return
+	}
+
+	var shards, replicas int64
+	var summary, detail string
+	if req.State.Raw.IsNull() {
+		shards, _, replicas, _ = config.Autoscaling.limits()
+	} else {
+		shards, replicas, summary, detail = r.nextAutoscalingStep(ctx, req, config.Autoscaling)
+	}
+
+	if shardsManaged {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("settings").AtName("number_of_shards"), types.Int64Value(shards))...)
+	}
+	if replicasManaged {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("settings").AtName("number_of_replicas"), types.Int64Value(replicas))...)
+	}
+
+	if summary != "" {
+		resp.Diagnostics.AddWarning(summary, detail)
+	}
+}
+
+// nextAutoscalingStep returns the shard and replica counts autoscaling plans for an existing index,
+// and a warning describing the scaling step when usage has reached a threshold.
+func (r *indicesResource) nextAutoscalingStep(ctx context.Context, req resource.ModifyPlanRequest, autoscaling *AutoscalingModel) (shards int64, replicas int64, summary string, detail string) {
+	var state IndexResourceModel
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		return 0, 0, "", ""
+	}
+
+	minShards, maxShards, minReplicas, maxReplicas := autoscaling.limits()
+	shards = max(state.Settings.NumberOfShards.ValueInt64(), minShards)
+	replicas = max(state.Settings.NumberOfReplicas.ValueInt64(), minReplicas)
+
+	if r.marqoClient == nil || (autoscaling.MemoryThresholdPercent.IsNull() && autoscaling.StorageThresholdPercent.IsNull()) {
+		return shards, replicas, "", ""
+	}
+
+	indexName := state.IndexName.ValueString()
+	stats, err := r.marqoClient.GetIndexStats(indexName)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not read stats of index %s for autoscaling: %s", indexName, err))
+		return shards, replicas, "", ""
+	}
+
+	var usage string
+	switch {
+	case !autoscaling.MemoryThresholdPercent.IsNull() && stats.Backend.MemoryUsedPercentage >= autoscaling.MemoryThresholdPercent.ValueFloat64():
+		usage = fmt.Sprintf("memory usage is %.1f%%, at or above the %.1f%% threshold",
+			stats.Backend.MemoryUsedPercentage, autoscaling.MemoryThresholdPercent.ValueFloat64())
+	case !autoscaling.StorageThresholdPercent.IsNull() && stats.Backend.StorageUsedPercentage >= autoscaling.StorageThresholdPercent.ValueFloat64():
+		usage = fmt.Sprintf("storage usage is %.1f%%, at or above the %.1f%% threshold",
+			stats.Backend.StorageUsedPercentage, autoscaling.StorageThresholdPercent.ValueFloat64())
+	default:
+		return shards, replicas, "", ""
+	}
+
+	switch {
+	case shards < maxShards:
+		shards++
+		return shards, replicas, "Index Autoscaling Planned",
+			fmt.Sprintf("Index %s %s, so number_of_shards is planned to increase to %d.", indexName, usage, shards)
+	case replicas < maxReplicas:
+		replicas++
+		return shards, replicas, "Index Autoscaling Planned",
+			fmt.Sprintf("Index %s %s, so number_of_replicas is planned to increase to %d.", indexName, usage, replicas)
+	default:
+		return shards, replicas, "Index Autoscaling Limit Reached", fmt.Sprintf("Index %s %s, but it already has max_shards (%d) and max_replicas (%d). "+
+			"Raise the autoscaling limits to scale it further.", indexName, usage, maxShards, maxReplicas)
+	}
+}
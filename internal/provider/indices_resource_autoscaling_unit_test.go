@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"marqo/go_marqo"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newTestStatsServer serves ListIndices with the given indexes and GetIndexStats with the given stats.
+func newTestStatsServer(t *testing.T, stats go_marqo.IndexStats, indices ...go_marqo.IndexDetail) *go_marqo.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body interface{} = go_marqo.IndexResponse{Results: indices}
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			body = stats
+		}
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &go_marqo.Client{BaseURL: server.URL, APIKey: "test-key"}
+}
+
+func testAutoscaling(minShards, maxShards, minReplicas, maxReplicas int64) *AutoscalingModel {
+	return &AutoscalingModel{
+		MinShards:               types.Int64Value(minShards),
+		MaxShards:               types.Int64Value(maxShards),
+		MinReplicas:             types.Int64Value(minReplicas),
+		MaxReplicas:             types.Int64Value(maxReplicas),
+		MemoryThresholdPercent:  types.Float64Value(80),
+		StorageThresholdPercent: types.Float64Null(),
+	}
+}
+
+func TestModifyPlanAutoscaling(t *testing.T) {
+	tests := []struct {
+		name             string
+		create           bool
+		autoscaling      *AutoscalingModel
+		memoryUsed       float64
+		expectedShards   int64
+		expectedReplicas int64
+		expectedWarning  string
+	}{
+		{name: "new index starts at minimums", create: true, autoscaling: testAutoscaling(2, 4, 1, 2), expectedShards: 2, expectedReplicas: 1},
+		{name: "below threshold", autoscaling: testAutoscaling(1, 4, 0, 2), memoryUsed: 50, expectedShards: 1, expectedReplicas: 0},
+		{name: "raised to minimums", autoscaling: testAutoscaling(2, 4, 0, 2), memoryUsed: 50, expectedShards: 2, expectedReplicas: 0,
+			expectedWarning: "Index Scaling Operation Planned"},
+		{name: "adds a shard", autoscaling: testAutoscaling(1, 4, 0, 2), memoryUsed: 85, expectedShards: 2, expectedReplicas: 0,
+			expectedWarning: "Index Autoscaling Planned; Index Scaling Operation Planned"},
+		{name: "adds a replica at max shards", autoscaling: testAutoscaling(1, 1, 0, 2), memoryUsed: 85, expectedShards: 1, expectedReplicas: 1,
+			expectedWarning: "Index Autoscaling Planned; Index Scaling Operation Planned"},
+		{name: "at limits", autoscaling: testAutoscaling(1, 1, 0, 0), memoryUsed: 85, expectedShards: 1, expectedReplicas: 0,
+			expectedWarning: "Index Autoscaling Limit Reached"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			live := go_marqo.IndexDetail{
+				IndexName:          "test-index",
+				IndexStatus:        "READY",
+				InferenceType:      "CPU.LARGE",
+				NumberOfInferences: 1,
+				StorageClass:       "BASIC",
+				NumberOfShards:     1,
+			}
+			stats := go_marqo.IndexStats{Backend: go_marqo.IndexStatsBackend{MemoryUsedPercentage: tt.memoryUsed}}
+			var r *indicesResource
+			if tt.create {
+				r = &indicesResource{marqoClient: newTestStatsServer(t, stats)}
+			} else {
+				r = &indicesResource{marqoClient: newTestStatsServer(t, stats, live)}
+			}
+
+			model := testIndexModel("READY")
+			model.Autoscaling = tt.autoscaling
+			state := newTestIndexState(t, model)
+			if tt.create {
+				state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)
+			}
+
+			model.Settings.NumberOfShards = types.Int64Null()
+			model.Settings.NumberOfReplicas = types.Int64Null()
+			configState := newTestIndexState(t, model)
+			config := tfsdk.Config{Schema: configState.Schema, Raw: configState.Raw}
+
+			// Terraform plans the prior counts for an unchanged index, and unknown counts otherwise
+			if tt.create {
+				model.Settings.NumberOfShards = types.Int64Unknown()
+				model.Settings.NumberOfReplicas = types.Int64Unknown()
+			} else {
+				model.Settings.NumberOfShards = types.Int64Value(1)
+				model.Settings.NumberOfReplicas = types.Int64Value(0)
+			}
+			planState := newTestIndexState(t, model)
+			plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan, Config: config}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			var shards, replicas types.Int64
+			resp.Plan.GetAttribute(ctx, path.Root("settings").AtName("number_of_shards"), &shards)
+			resp.Plan.GetAttribute(ctx, path.Root("settings").AtName("number_of_replicas"), &replicas)
+			if shards.ValueInt64() != tt.expectedShards || replicas.ValueInt64() != tt.expectedReplicas {
+				t.Errorf("expected %d shards and %d replicas, got %s and %s", tt.expectedShards, tt.expectedReplicas, shards, replicas)
+			}
+			if got := diagnosticSummaries(resp.Diagnostics.Warnings()); got != tt.expectedWarning {
+				t.Errorf("expected warning %q, got %q", tt.expectedWarning, got)
+			}
+		})
+	}
+}
+
+func TestValidateConfigAutoscaling(t *testing.T) {
+	tests := []struct {
+		name          string
+		autoscaling   *AutoscalingModel
+		shards        types.Int64
+		expectedError string
+	}{
+		{name: "counts without autoscaling", shards: types.Int64Value(1)},
+		{name: "missing shards without autoscaling", shards: types.Int64Null(), expectedError: "Missing Attribute Configuration"},
+		{name: "autoscaling manages shards", autoscaling: testAutoscaling(1, 2, 0, 0), shards: types.Int64Null()},
+		{name: "inverted limits", autoscaling: testAutoscaling(3, 2, 0, 0), shards: types.Int64Null(), expectedError: "Invalid Autoscaling Configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			model := testIndexModel("READY")
+			model.Autoscaling = tt.autoscaling
+			model.Settings.NumberOfShards = tt.shards
+			configState := newTestIndexState(t, model)
+
+			resp := &resource.ValidateConfigResponse{}
+			(&indicesResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: configState.Schema, Raw: configState.Raw},
+			}, resp)
+
+			if got := diagnosticSummaries(resp.Diagnostics.Errors()); got != tt.expectedError {
+				t.Errorf("expected error %q, got %q", tt.expectedError, got)
+			}
+		})
+	}
+}
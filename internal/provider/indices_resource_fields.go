@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// indexTypeSemiStructured is the index type whose fields are discovered from the documents
+// added to it, and whose all_fields can be extended in place.
+const indexTypeSemiStructured = "semi-structured"
+
+var discoveredFieldAttrTypes = map[string]attr.Type{
+	"name":     types.StringType,
+	"type":     types.StringType,
+	"features": types.SetType{ElemType: types.StringType},
+}
+
+var discoveredFieldObjectType = types.ObjectType{AttrTypes: discoveredFieldAttrTypes}
+
+// discoveredFieldsSchema returns the schema of the computed discovered_fields attribute.
+func discoveredFieldsSchema() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Computed: true,
+		Description: "The fields of a semi-structured index that Marqo discovered from its documents and that are not " +
+			"declared in settings.all_fields. Empty for other index types.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Computed: true},
+				"type": schema.StringAttribute{Computed: true},
+				"features": schema.SetAttribute{
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+	}
+}
+
+// noDiscoveredFields is the discovered_fields value of an index with no discovered fields.
+func noDiscoveredFields() types.Set {
+	return types.SetValueMust(discoveredFieldObjectType, []attr.Value{})
+}
+
+// splitDiscoveredFields moves the fields a semi-structured index reports beyond the configured
+// ones from all_fields into discovered_fields, so that fields Marqo adds as documents are indexed
+// do not show up as changes to the configuration. Other index types have no discovered fields.
+func splitDiscoveredFields(model *IndexResourceModel, configured []AllFieldInput) {
+	model.DiscoveredFields = noDiscoveredFields()
+	if model.Settings.Type.ValueString() != indexTypeSemiStructured {
+		return
+	}
+
+	configuredByName := allFieldsByName(configured)
+	var declared []AllFieldInput
+	var discovered []attr.Value
+	for _, field := range model.Settings.AllFields {
+		if _, ok := configuredByName[field.Name.ValueString()]; ok {
+			declared = append(declared, field)
+			continue
+		}
+		features := make([]attr.Value, 0, len(field.Features))
+		for _, feature := range field.Features {
+			features = append(features, feature)
+		}
+		discovered = append(discovered, types.ObjectValueMust(discoveredFieldAttrTypes, map[string]attr.Value{
+			"name":     field.Name,
+			"type":     field.Type,
+			"features": types.SetValueMust(types.StringType, features),
+		}))
+	}
+
+	model.Settings.AllFields = declared
+	if len(discovered) > 0 {
+		model.DiscoveredFields = types.SetValueMust(discoveredFieldObjectType, discovered)
+	}
+}
+
+// fieldsOnlyAdded reports whether to keeps every field of from unchanged, so that the
+// difference between them only adds fields.
+func fieldsOnlyAdded(from []AllFieldInput, to []AllFieldInput) bool {
+	toByName := allFieldsByName(to)
+	for name, field := range allFieldsByName(from) {
+		if planned, ok := toByName[name]; !ok || !reflect.DeepEqual(field, planned) {
+			return false
+		}
+	}
+	return true
+}
+
+// allFieldsRequiresReplace replaces the index when all_fields changes, except when fields are
+// only added to a semi-structured index, which Marqo applies in place.
+func allFieldsRequiresReplace() planmodifier.Set {
+	return setplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.SetRequest, resp *setplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = true
+
+			var indexType types.String
+			if diags := req.Plan.GetAttribute(ctx, path.Root("settings").AtName("type"), &indexType); diags.HasError() ||
+				indexType.ValueString() != indexTypeSemiStructured {
+				return
+			}
+
+			var from, to []AllFieldInput
+			if diags := req.StateValue.ElementsAs(ctx, &from, false); diags.HasError() {
+				return
+			}
+			if diags := req.PlanValue.ElementsAs(ctx, &to, false); diags.HasError() {
+				return
+			}
+			resp.RequiresReplace = !fieldsOnlyAdded(from, to)
+		},
+		"Adding fields to a semi-structured index updates it in place; any other change replaces the index.",
+		"Adding fields to a semi-structured index updates it in place; any other change replaces the index.",
+	)
+}
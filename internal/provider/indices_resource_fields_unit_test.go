@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testField(name string, fieldType string, features ...string) AllFieldInput {
+	field := AllFieldInput{Name: types.StringValue(name), Type: types.StringValue(fieldType)}
+	for _, feature := range features {
+		field.Features = append(field.Features, types.StringValue(feature))
+	}
+	return field
+}
+
+func TestSplitDiscoveredFields(t *testing.T) {
+	reported := []AllFieldInput{
+		testField("title", "text", "lexical_search"),
+		testField("price", "float", "filter"),
+	}
+
+	tests := []struct {
+		name               string
+		indexType          string
+		configured         []AllFieldInput
+		expectedDeclared   []string
+		expectedDiscovered int
+	}{
+		{
+			name:               "semi-structured with declared field",
+			indexType:          indexTypeSemiStructured,
+			configured:         []AllFieldInput{testField("title", "text", "lexical_search")},
+			expectedDeclared:   []string{"title"},
+			expectedDiscovered: 1,
+		},
+		{
+			name:               "semi-structured without declared fields",
+			indexType:          indexTypeSemiStructured,
+			expectedDiscovered: 2,
+		},
+		{
+			name:             "structured",
+			indexType:        "structured",
+			expectedDeclared: []string{"title", "price"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := testIndexModel("READY")
+			model.Settings.Type = types.StringValue(tt.indexType)
+			model.Settings.AllFields = reported
+
+			splitDiscoveredFields(model, tt.configured)
+
+			var declared []string
+			for _, field := range model.Settings.AllFields {
+				declared = append(declared, field.Name.ValueString())
+			}
+			if len(declared) != len(tt.expectedDeclared) {
+				t.Fatalf("expected declared fields %v, got %v", tt.expectedDeclared, declared)
+			}
+			for i := range declared {
+				if declared[i] != tt.expectedDeclared[i] {
+					t.Errorf("expected declared fields %v, got %v", tt.expectedDeclared, declared)
+				}
+			}
+			if model.DiscoveredFields.IsNull() || model.DiscoveredFields.IsUnknown() {
+				t.Fatalf("expected known discovered_fields, got %s", model.DiscoveredFields)
+			}
+			if got := len(model.DiscoveredFields.Elements()); got != tt.expectedDiscovered {
+				t.Errorf("expected %d discovered fields, got %d", tt.expectedDiscovered, got)
+			}
+		})
+	}
+}
+
+func TestFieldsOnlyAdded(t *testing.T) {
+	title := testField("title", "text", "lexical_search")
+	price := testField("price", "float", "filter")
+
+	tests := []struct {
+		name     string
+		from     []AllFieldInput
+		to       []AllFieldInput
+		expected bool
+	}{
+		{name: "field added", from: []AllFieldInput{title}, to: []AllFieldInput{price, title}, expected: true},
+		{name: "first field added", to: []AllFieldInput{title}, expected: true},
+		{name: "field removed", from: []AllFieldInput{title, price}, to: []AllFieldInput{title}, expected: false},
+		{name: "field changed", from: []AllFieldInput{title}, to: []AllFieldInput{testField("title", "text", "filter")}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldsOnlyAdded(tt.from, tt.to); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAllFieldsRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	allFieldsPath := path.Root("settings").AtName("all_fields")
+	title := testField("title", "text", "lexical_search")
+	price := testField("price", "float", "filter")
+
+	tests := []struct {
+		name            string
+		indexType       string
+		from            []AllFieldInput
+		to              []AllFieldInput
+		expectedReplace bool
+	}{
+		{name: "semi-structured field added", indexType: indexTypeSemiStructured, from: []AllFieldInput{title}, to: []AllFieldInput{title, price}, expectedReplace: false},
+		{name: "semi-structured field removed", indexType: indexTypeSemiStructured, from: []AllFieldInput{title, price}, to: []AllFieldInput{title}, expectedReplace: true},
+		{name: "structured field added", indexType: "structured", from: []AllFieldInput{title}, to: []AllFieldInput{title, price}, expectedReplace: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateModel := testIndexModel("READY")
+			stateModel.Settings.Type = types.StringValue(tt.indexType)
+			stateModel.Settings.AllFields = tt.from
+			state := newTestIndexState(t, stateModel)
+
+			planModel := testIndexModel("READY")
+			planModel.Settings.Type = types.StringValue(tt.indexType)
+			planModel.Settings.AllFields = tt.to
+			planState := newTestIndexState(t, planModel)
+
+			var stateValue, planValue types.Set
+			state.GetAttribute(ctx, allFieldsPath, &stateValue)
+			planState.GetAttribute(ctx, allFieldsPath, &planValue)
+
+			req := planmodifier.SetRequest{
+				Path:        allFieldsPath,
+				StateValue:  stateValue,
+				PlanValue:   planValue,
+				ConfigValue: planValue,
+				State:       state,
+			}
+			req.Plan.Schema = planState.Schema
+			req.Plan.Raw = planState.Raw
+			req.Config.Schema = planState.Schema
+			req.Config.Raw = planState.Raw
+			resp := &planmodifier.SetResponse{PlanValue: planValue}
+
+			allFieldsRequiresReplace().PlanModifySet(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tt.expectedReplace {
+				t.Errorf("expected replace %t, got %t", tt.expectedReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
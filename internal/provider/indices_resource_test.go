@@ -2,10 +2,14 @@ package provider
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -231,6 +235,11 @@ func TestAccResourceMinimalIndex(t *testing.T) {
 					resource.TestCheckResourceAttr("marqo_index.test", "settings.number_of_replicas", "0"),
 					resource.TestCheckResourceAttr("marqo_index.test", "settings.number_of_shards", "1"),
 					resource.TestCheckResourceAttr("marqo_index.test", "settings.storage_class", "marqo.basic"),
+					// Server defaults are recorded for settings left out of the configuration
+					resource.TestCheckResourceAttr("marqo_index.test", "settings.vector_numeric_type", "float"),
+					resource.TestCheckResourceAttr("marqo_index.test", "settings.normalize_embeddings", "true"),
+					resource.TestCheckResourceAttr("marqo_index.test", "settings.text_preprocessing.split_method", "sentence"),
+					resource.TestCheckResourceAttr("marqo_index.test", "settings.ann_parameters.space_type", "prenormalized-angular"),
 					resource.TestCheckResourceAttr("marqo_index.test", "timeouts.create", "60m"),
 					resource.TestCheckResourceAttr("marqo_index.test", "timeouts.update", "60m"),
 					resource.TestCheckResourceAttr("marqo_index.test", "timeouts.delete", "45m"),
@@ -240,6 +249,11 @@ func TestAccResourceMinimalIndex(t *testing.T) {
 					},
 				),
 			},
+			// The minimal configuration converges after one apply
+			{
+				Config:   testAccResourceMinimalIndexConfig(minimal_index_name),
+				PlanOnly: true,
+			},
 			// Import testing
 			{
 				ResourceName:                         "marqo_index.test",
@@ -515,6 +529,203 @@ func TestAccResourceScalingIndex(t *testing.T) {
 	})
 }
 
+// indexExamples are the examples under examples/ that create a marqo_index.
+var indexExamples = []string{
+	"create_index",
+	"create_index_custom_model",
+	"create_index_languagebind",
+	"create_semi_structured_index",
+	"create_structured_index",
+	"minimal",
+}
+
+// exampleIndexConfig returns the marqo_index resource of an example as marqo_index.test, with
+// index_name replaced by name.
+func exampleIndexConfig(example string, name string) (string, error) {
+	content, err := os.ReadFile(filepath.Join("..", "..", "examples", example, example+".tf"))
+	if err != nil {
+		return "", err
+	}
+
+	const header = `resource "marqo_index" "example" {`
+	start := strings.Index(string(content), header)
+	if start < 0 {
+		return "", fmt.Errorf("example %s has no marqo_index.example resource", example)
+	}
+	depth := 0
+	for end := start; end < len(content); end++ {
+		switch content[end] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				block := strings.Replace(string(content[start:end+1]), header, `resource "marqo_index" "test" {`, 1)
+				return regexp.MustCompile(`index_name\s*=\s*"[^"]*"`).ReplaceAllString(block, fmt.Sprintf("index_name = %q", name)), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("example %s has an unterminated marqo_index.example resource", example)
+}
+
+// TestAccResourceImportExampleIndex tests that importing an index created from each example
+// produces an empty plan against the example's configuration.
+func TestAccResourceImportExampleIndex(t *testing.T) {
+	for _, example := range indexExamples {
+		example := example
+		t.Run(example, func(t *testing.T) {
+			t.Parallel() // Enable parallel testing
+			import_example_index_name := fmt.Sprintf("donotdelete_import_ex_%s", randomString(6))
+			config, err := exampleIndexConfig(example, import_example_index_name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					// Check if index exists and delete if it does, then create it from the example
+					{
+						Config: testAccEmptyConfig(),
+						Check: resource.ComposeTestCheckFunc(
+							testAccCheckIndexExistsAndDelete(import_example_index_name),
+						),
+					},
+					{
+						Config: config,
+						Check: resource.ComposeTestCheckFunc(
+							testAccCheckIndexIsReady(import_example_index_name),
+						),
+					},
+					// Import over the existing state and keep the imported state
+					{
+						Config:                               config,
+						ResourceName:                         "marqo_index.test",
+						ImportState:                          true,
+						ImportStatePersist:                   true,
+						ImportStateId:                        import_example_index_name,
+						ImportStateVerifyIdentifierAttribute: "index_name",
+					},
+					// The first plan after import is empty
+					{
+						Config:   config,
+						PlanOnly: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+// TestAccResourceAdoptExistingIndex tests adopting an index that was created outside of Terraform.
+func TestAccResourceAdoptExistingIndex(t *testing.T) {
+	t.Parallel() // Enable parallel testing
+	adopt_index_name := fmt.Sprintf("donotdelete_adopt_%s", randomString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Check if index exists and delete if it does, then create it outside of Terraform
+			{
+				Config: testAccEmptyConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIndexExistsAndDelete(adopt_index_name),
+					testAccCreateIndexOutsideTerraform(adopt_index_name),
+					testAccCheckIndexIsReady(adopt_index_name),
+				),
+			},
+			// Adopting with different settings must fail and list the differences
+			{
+				Config:      testAccResourceAdoptExistingIndexConfig(adopt_index_name, "marqo.GPU"),
+				ExpectError: regexp.MustCompile("Existing Index Settings Differ"),
+			},
+			// Adopting with matching settings imports the index
+			{
+				Config: testAccResourceAdoptExistingIndexConfig(adopt_index_name, "marqo.CPU.large"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("marqo_index.test", "index_name", adopt_index_name),
+					resource.TestCheckResourceAttr("marqo_index.test", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("marqo_index.test", "settings.inference_type", "marqo.CPU.large"),
+					resource.TestCheckResourceAttrSet("marqo_index.test", "marqo_endpoint"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceAdoptExistingIndexConfig(name string, inferenceType string) string {
+	return fmt.Sprintf(`
+		resource "marqo_index" "test" {
+			index_name = "%s"
+			adopt_existing = true
+			settings = {
+				type = "unstructured"
+				model = "open_clip/ViT-L-14/laion2b_s32b_b82k"
+				inference_type = "%s"
+				number_of_inferences = 1
+				number_of_replicas = 0
+				number_of_shards = 1
+				storage_class = "marqo.basic"
+			}
+		}
+	`, name, inferenceType)
+}
+
+// TestAccResourceAsyncCreateIndex tests creating an index without waiting for it to become READY.
+func TestAccResourceAsyncCreateIndex(t *testing.T) {
+	t.Parallel() // Enable parallel testing
+	async_index_name := fmt.Sprintf("donotdelete_async_%s", randomString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Check if index exists and delete if it does
+			{
+				Config: testAccEmptyConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIndexExistsAndDelete(async_index_name),
+				),
+			},
+			// Apply returns as soon as the create request is accepted
+			{
+				Config: testAccResourceAsyncIndexConfig(async_index_name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("marqo_index.test", "index_name", async_index_name),
+					resource.TestCheckResourceAttr("marqo_index.test", "wait_for_ready", "false"),
+					resource.TestCheckResourceAttr("marqo_index.test", "index_status", "CREATING"),
+				),
+			},
+			// The next refresh resumes the wait
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("marqo_index.test", "index_status", "READY"),
+					resource.TestCheckResourceAttrSet("marqo_index.test", "marqo_endpoint"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceAsyncIndexConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "marqo_index" "test" {
+			index_name = "%s"
+			wait_for_ready = false
+			settings = {
+				type = "unstructured"
+				model = "open_clip/ViT-L-14/laion2b_s32b_b82k"
+				inference_type = "marqo.CPU.large"
+				number_of_inferences = 1
+				number_of_replicas = 0
+				number_of_shards = 1
+				storage_class = "marqo.basic"
+			}
+		}
+	`, name)
+}
+
 // TestAccResourceInvalidUpdate tests that attempting to modify non-modifiable fields fails.
 func TestAccResourceInvalidUpdate(t *testing.T) {
 	t.Parallel() // Enable parallel testing
@@ -543,15 +754,83 @@ func TestAccResourceInvalidUpdate(t *testing.T) {
 					testAccCheckIndexIsReady(invalid_update_index_name),
 				),
 			},
-			// Attempt to modify a non-modifiable field (model)
+			// Modifying a non-modifiable field (model) plans a replacement
 			{
-				Config:      testAccResourceInvalidUpdateConfig(invalid_update_index_name),
-				ExpectError: regexp.MustCompile("Cannot Modify Index Model"),
+				Config:             testAccResourceInvalidUpdateConfig(invalid_update_index_name),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("marqo_index.test", plancheck.ResourceActionReplace),
+					},
+				},
 			},
 		},
 	})
 }
 
+// TestAccResourceCreateBeforeDestroyIndex tests that a generated name lets a replacement
+// index be created before the old one is deleted.
+func TestAccResourceCreateBeforeDestroyIndex(t *testing.T) {
+	t.Parallel() // Enable parallel testing
+	name_prefix := fmt.Sprintf("donotdelete_cbd_%s_", randomString(6))
+	var firstIndexName string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCreateBeforeDestroyConfig(name_prefix, "open_clip/ViT-L-14/laion2b_s32b_b82k"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("marqo_index.test", "index_name", regexp.MustCompile("^"+name_prefix)),
+					func(s *terraform.State) error {
+						firstIndexName = s.RootModule().Resources["marqo_index.test"].Primary.Attributes["index_name"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceCreateBeforeDestroyConfig(name_prefix, "open_clip/ViT-B-32/laion2b_s34b_b79k"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("marqo_index.test", plancheck.ResourceActionCreateBeforeDestroy),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("marqo_index.test", "index_name", regexp.MustCompile("^"+name_prefix)),
+					func(s *terraform.State) error {
+						indexName := s.RootModule().Resources["marqo_index.test"].Primary.Attributes["index_name"]
+						if indexName == firstIndexName {
+							return fmt.Errorf("expected a new index name, got %s again", indexName)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceCreateBeforeDestroyConfig(namePrefix string, model string) string {
+	return fmt.Sprintf(`
+		resource "marqo_index" "test" {
+			name_prefix = "%s"
+			settings = {
+				type = "unstructured"
+				model = "%s"
+				inference_type = "marqo.CPU.large"
+				number_of_inferences = 1
+				number_of_replicas = 0
+				number_of_shards = 1
+				storage_class = "marqo.basic"
+			}
+			lifecycle {
+				create_before_destroy = true
+			}
+		}
+	`, namePrefix, model)
+}
+
 func testAccResourceInvalidUpdateConfig(name string) string {
 	return fmt.Sprintf(`
 		resource "marqo_index" "test" {
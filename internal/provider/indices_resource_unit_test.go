@@ -0,0 +1,761 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"marqo/go_marqo"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIndexSettingsDiff(t *testing.T) {
+	existing := IndexSettingsModel{
+		Type:                types.StringValue("unstructured"),
+		Model:               types.StringValue("open_clip/ViT-L-14/laion2b_s32b_b82k"),
+		InferenceType:       types.StringValue("marqo.CPU.large"),
+		NumberOfInferences:  types.Int64Value(1),
+		StorageClass:        types.StringValue("marqo.basic"),
+		NumberOfShards:      types.Int64Value(1),
+		NumberOfReplicas:    types.Int64Value(0),
+		VectorNumericType:   types.StringValue("float"),
+		NormalizeEmbeddings: types.BoolValue(true),
+		TextPreprocessing: &TextPreprocessingModelCreate{
+			SplitLength:  types.Int64Value(2),
+			SplitMethod:  types.StringValue("sentence"),
+			SplitOverlap: types.Int64Value(0),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		desired  IndexSettingsModel
+		expected []string
+	}{
+		{
+			name: "matching required settings",
+			desired: IndexSettingsModel{
+				Type:               types.StringValue("unstructured"),
+				Model:              types.StringValue("open_clip/ViT-L-14/laion2b_s32b_b82k"),
+				InferenceType:      types.StringValue("marqo.CPU.large"),
+				NumberOfInferences: types.Int64Value(1),
+				StorageClass:       types.StringValue("marqo.basic"),
+				NumberOfShards:     types.Int64Value(1),
+				NumberOfReplicas:   types.Int64Value(0),
+			},
+			expected: nil,
+		},
+		{
+			name: "different scalar and nested settings",
+			desired: IndexSettingsModel{
+				Type:               types.StringValue("unstructured"),
+				Model:              types.StringValue("open_clip/ViT-L-14/laion2b_s32b_b82k"),
+				InferenceType:      types.StringValue("marqo.GPU"),
+				NumberOfInferences: types.Int64Value(1),
+				StorageClass:       types.StringValue("marqo.basic"),
+				NumberOfShards:     types.Int64Value(1),
+				NumberOfReplicas:   types.Int64Value(2),
+				TextPreprocessing: &TextPreprocessingModelCreate{
+					SplitLength:  types.Int64Value(4),
+					SplitMethod:  types.StringNull(),
+					SplitOverlap: types.Int64Null(),
+				},
+			},
+			expected: []string{"inference_type", "number_of_replicas", "text_preprocessing.split_length"},
+		},
+		{
+			name: "optional setting missing from existing index",
+			desired: IndexSettingsModel{
+				Type:                  types.StringValue("unstructured"),
+				FilterStringMaxLength: types.Int64Value(50),
+			},
+			expected: []string{"filter_string_max_length"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := indexSettingsDiff(existing, tt.desired)
+			if len(diffs) != len(tt.expected) {
+				t.Fatalf("expected %d differences, got %d: %v", len(tt.expected), len(diffs), diffs)
+			}
+			for i, name := range tt.expected {
+				if !strings.HasPrefix(strings.TrimSpace(diffs[i]), name+":") {
+					t.Errorf("expected difference %d to be for %s, got %q", i, name, diffs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeIndexSettings(t *testing.T) {
+	settings := IndexSettingsModel{
+		InferenceType: types.StringValue("CPU.SMALL"),
+		StorageClass:  types.StringValue("BALANCED"),
+	}
+
+	normalizeIndexSettings(&settings)
+
+	if settings.InferenceType.ValueString() != "marqo.CPU.small" {
+		t.Errorf("expected inference type marqo.CPU.small, got %s", settings.InferenceType.ValueString())
+	}
+	if settings.StorageClass.ValueString() != "marqo.balanced" {
+		t.Errorf("expected storage class marqo.balanced, got %s", settings.StorageClass.ValueString())
+	}
+}
+
+// newTestIndexState builds a resource state for the marqo_index schema from a model.
+func newTestIndexState(t *testing.T, model *IndexResourceModel) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&indicesResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+	return state
+}
+
+func testIndexModel(status string) *IndexResourceModel {
+	return &IndexResourceModel{
+		IndexName:        types.StringValue("test-index"),
+		MarqoEndpoint:    types.StringValue("https://test-index.marqo.ai"),
+		IndexStatus:      types.StringValue(status),
+		DiscoveredFields: noDiscoveredFields(),
+		Settings: IndexSettingsModel{
+			Type:               types.StringValue("unstructured"),
+			Model:              types.StringValue("open_clip/ViT-L-14/laion2b_s32b_b82k"),
+			InferenceType:      types.StringValue("marqo.CPU.large"),
+			NumberOfInferences: types.Int64Value(1),
+			StorageClass:       types.StringValue("marqo.basic"),
+			NumberOfShards:     types.Int64Value(1),
+			NumberOfReplicas:   types.Int64Value(0),
+		},
+	}
+}
+
+func TestModifyPlanReplacesFailedIndex(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          string
+		expectedReplace bool
+	}{
+		{name: "ready index", status: "READY", expectedReplace: false},
+		{name: "failed index", status: "FAILED", expectedReplace: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := newTestIndexState(t, testIndexModel(tt.status))
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			(&indicesResource{}).ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if replaced := len(resp.RequiresReplace) > 0; replaced != tt.expectedReplace {
+				t.Errorf("expected replace %t, got %t", tt.expectedReplace, replaced)
+			}
+		})
+	}
+}
+
+func TestConfigFormIndexSettings(t *testing.T) {
+	settings := IndexSettingsModel{
+		Type:                         types.StringValue("structured"),
+		InferenceType:                types.StringValue("GPU"),
+		StorageClass:                 types.StringValue("PERFORMANCE"),
+		VectorNumericType:            types.StringNull(),
+		NormalizeEmbeddings:          types.BoolNull(),
+		TreatUrlsAndPointersAsImages: types.BoolValue(false),
+		AllFields: []AllFieldInput{
+			{
+				Name:            types.StringValue("text_field"),
+				Type:            types.StringValue("text"),
+				Features:        []types.String{},
+				DependentFields: map[string]types.Float64{},
+			},
+		},
+		TensorFields:       []string{},
+		ImagePreprocessing: &ImagePreprocessingModel{PatchMethod: types.StringValue("")},
+	}
+
+	configFormIndexSettings(&settings)
+
+	if settings.InferenceType.ValueString() != "marqo.GPU" || settings.StorageClass.ValueString() != "marqo.performance" {
+		t.Errorf("expected normalized aliases, got %s and %s", settings.InferenceType, settings.StorageClass)
+	}
+	if settings.AllFields[0].Features != nil || settings.AllFields[0].DependentFields != nil {
+		t.Errorf("expected empty field attributes to be null, got %+v", settings.AllFields[0])
+	}
+	if settings.TensorFields != nil || settings.ImagePreprocessing != nil {
+		t.Errorf("expected empty settings to be null, got %v and %+v", settings.TensorFields, settings.ImagePreprocessing)
+	}
+	if settings.VectorNumericType.ValueString() != defaultVectorNumericType || !settings.NormalizeEmbeddings.ValueBool() {
+		t.Errorf("expected API defaults to be filled in, got %s and %s", settings.VectorNumericType, settings.NormalizeEmbeddings)
+	}
+	if settings.TextPreprocessing == nil || settings.AnnParameters == nil {
+		t.Errorf("expected default text_preprocessing and ann_parameters to be filled in")
+	}
+	if !settings.TreatUrlsAndPointersAsImages.IsNull() {
+		t.Errorf("expected unstructured-only settings to be null for structured indexes")
+	}
+}
+
+func TestPreserveConfigForm(t *testing.T) {
+	prior := IndexSettingsModel{
+		Type:                  types.StringValue("unstructured"),
+		NumberOfReplicas:      types.Int64Value(0),
+		VectorNumericType:     types.StringNull(),
+		NormalizeEmbeddings:   types.BoolNull(),
+		FilterStringMaxLength: types.Int64Null(),
+		TensorFields:          []string{},
+		ImagePreprocessing:    &ImagePreprocessingModel{PatchMethod: types.StringNull()},
+	}
+
+	refreshed := IndexSettingsModel{
+		Type:                  types.StringValue("unstructured"),
+		NumberOfReplicas:      types.Int64Value(1),
+		FilterStringMaxLength: types.Int64Value(defaultFilterStringMaxLength),
+	}
+	configFormIndexSettings(&refreshed)
+	preserveConfigForm(&refreshed, prior)
+
+	if !refreshed.FilterStringMaxLength.IsNull() {
+		t.Errorf("expected defaulted filter_string_max_length to stay null, got %s", refreshed.FilterStringMaxLength)
+	}
+	if refreshed.VectorNumericType.ValueString() != defaultVectorNumericType || refreshed.TextPreprocessing == nil || refreshed.AnnParameters == nil {
+		t.Errorf("expected computed settings to be filled in with Marqo's defaults")
+	}
+	if refreshed.TensorFields == nil || refreshed.ImagePreprocessing == nil {
+		t.Errorf("expected empty configured values to be kept")
+	}
+	if refreshed.NumberOfReplicas.ValueInt64() != 1 {
+		t.Errorf("expected changed replicas to be refreshed, got %s", refreshed.NumberOfReplicas)
+	}
+
+	// A setting that moved away from the default is reported even when it was left null
+	refreshed.FilterStringMaxLength = types.Int64Value(100)
+	preserveConfigForm(&refreshed, prior)
+	if refreshed.FilterStringMaxLength.ValueInt64() != 100 {
+		t.Errorf("expected non-default filter_string_max_length to be refreshed, got %s", refreshed.FilterStringMaxLength)
+	}
+
+	diffs := indexSettingsDiff(refreshed, prior)
+	if len(diffs) != 1 {
+		t.Errorf("expected one difference from the prior state, got %v", diffs)
+	}
+
+	// Video preprocessing is kept when the index does not report it and refreshed when it does
+	prior.VideoPreprocessing = &VideoPreprocessingModelCreate{SplitLength: types.Int64Value(5), SplitOverlap: types.Int64Value(1)}
+	refreshed.VideoPreprocessing = nil
+	preserveConfigForm(&refreshed, prior)
+	if refreshed.VideoPreprocessing != prior.VideoPreprocessing {
+		t.Errorf("expected unreported video_preprocessing to be kept, got %+v", refreshed.VideoPreprocessing)
+	}
+	reported := &VideoPreprocessingModelCreate{SplitLength: types.Int64Value(10), SplitOverlap: types.Int64Value(1)}
+	refreshed.VideoPreprocessing = reported
+	preserveConfigForm(&refreshed, prior)
+	if refreshed.VideoPreprocessing != reported {
+		t.Errorf("expected reported video_preprocessing to be refreshed, got %+v", refreshed.VideoPreprocessing)
+	}
+}
+
+func TestIndexResourceSchemaDefaults(t *testing.T) {
+	ctx := context.Background()
+	resp := &resource.SchemaResponse{}
+	(&indicesResource{}).Schema(ctx, resource.SchemaRequest{}, resp)
+
+	if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+
+	for _, name := range []string{"vector_numeric_type", "normalize_embeddings", "text_preprocessing", "ann_parameters"} {
+		attribute, diags := resp.Schema.AttributeAtPath(ctx, path.Root("settings").AtName(name))
+		if diags.HasError() {
+			t.Fatalf("missing attribute %s: %v", name, diags)
+		}
+		if !attribute.IsOptional() || !attribute.IsComputed() {
+			t.Errorf("expected %s to be optional and computed", name)
+		}
+	}
+
+	// The object defaults decode into the same values as the defaults used for refreshed settings
+	var text TextPreprocessingModelCreate
+	if diags := defaultTextPreprocessingObject().As(ctx, &text, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("failed to decode text_preprocessing default: %v", diags)
+	}
+	if text != *defaultTextPreprocessing() {
+		t.Errorf("text_preprocessing default %+v does not match %+v", text, *defaultTextPreprocessing())
+	}
+	var ann AnnParametersModelCreate
+	if diags := defaultAnnParametersObject().As(ctx, &ann, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("failed to decode ann_parameters default: %v", diags)
+	}
+	if ann.SpaceType.ValueString() != defaultSpaceType || *ann.Parameters != *defaultAnnParameters().Parameters {
+		t.Errorf("ann_parameters default %+v does not match the refreshed default", ann)
+	}
+}
+
+func TestAllFieldsChanges(t *testing.T) {
+	field := func(name string, features ...string) AllFieldInput {
+		f := AllFieldInput{Name: types.StringValue(name), Type: types.StringValue("text")}
+		for _, feature := range features {
+			f.Features = append(f.Features, types.StringValue(feature))
+		}
+		return f
+	}
+
+	existing := []AllFieldInput{
+		field("title", "lexical_search", "filter"),
+		field("description"),
+	}
+
+	tests := []struct {
+		name     string
+		desired  []AllFieldInput
+		expected []string
+	}{
+		{
+			name:     "reordered fields and features",
+			desired:  []AllFieldInput{field("description"), field("title", "filter", "lexical_search")},
+			expected: nil,
+		},
+		{
+			name:     "added field",
+			desired:  []AllFieldInput{field("title", "lexical_search", "filter"), field("description"), field("tags")},
+			expected: []string{`added field "tags"`},
+		},
+		{
+			name:     "removed and changed fields",
+			desired:  []AllFieldInput{field("title", "lexical_search")},
+			expected: []string{`changed field "title"`, `removed field "description"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := allFieldsChanges(existing, tt.desired)
+			if strings.Join(changes, "; ") != strings.Join(tt.expected, "; ") {
+				t.Errorf("expected %v, got %v", tt.expected, changes)
+			}
+		})
+	}
+}
+
+// newTestIndexServer serves ListIndices with the given indexes.
+func newTestIndexServer(t *testing.T, indices ...go_marqo.IndexDetail) *go_marqo.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(go_marqo.IndexResponse{Results: indices}); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &go_marqo.Client{BaseURL: server.URL, APIKey: "test-key"}
+}
+
+func TestModifyPlanChecksLiveIndex(t *testing.T) {
+	live := go_marqo.IndexDetail{
+		IndexName:          "test-index",
+		IndexStatus:        "READY",
+		Type:               "unstructured",
+		Model:              "open_clip/ViT-L-14/laion2b_s32b_b82k",
+		InferenceType:      "CPU.LARGE",
+		NumberOfInferences: 1,
+		StorageClass:       "BASIC",
+		NumberOfShards:     2,
+		NumberOfReplicas:   1,
+	}
+
+	tests := []struct {
+		name            string
+		create          bool
+		adoptExisting   bool
+		status          string
+		shards          int64
+		expectedError   string
+		expectedWarning string
+	}{
+		{name: "create over existing index", create: true, shards: 2, expectedError: "Index Already Exists"},
+		{name: "create adopting existing index", create: true, adoptExisting: true, shards: 2},
+		{name: "create adopting different index", create: true, adoptExisting: true, shards: 3, expectedError: "Existing Index Settings Differ"},
+		{name: "shard decrease", status: "READY", shards: 1, expectedError: "Invalid Shard Count"},
+		{name: "update while modifying", status: "MODIFYING", shards: 3, expectedWarning: "Index Scaling Operation Planned; Index Not Ready"},
+		{name: "valid update", status: "READY", shards: 3, expectedWarning: "Index Scaling Operation Planned"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			detail := live
+			if tt.status != "" {
+				detail.IndexStatus = tt.status
+			}
+			r := &indicesResource{marqoClient: newTestIndexServer(t, detail)}
+
+			model := testIndexModel("READY")
+			model.Settings.NumberOfReplicas = types.Int64Value(1)
+			state := newTestIndexState(t, model)
+
+			model.Settings.NumberOfShards = types.Int64Value(tt.shards)
+			model.AdoptExisting = types.BoolValue(tt.adoptExisting)
+			planState := newTestIndexState(t, model)
+			plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+
+			if tt.create {
+				state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+			if got := diagnosticSummaries(resp.Diagnostics.Errors()); got != tt.expectedError {
+				t.Errorf("expected error %q, got %q", tt.expectedError, got)
+			}
+			if got := diagnosticSummaries(resp.Diagnostics.Warnings()); got != tt.expectedWarning {
+				t.Errorf("expected warning %q, got %q", tt.expectedWarning, got)
+			}
+		})
+	}
+}
+
+func diagnosticSummaries(diags diag.Diagnostics) string {
+	summaries := make([]string, 0, len(diags))
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary())
+	}
+	return strings.Join(summaries, "; ")
+}
+
+func TestValidateConfigIndexName(t *testing.T) {
+	tests := []struct {
+		name          string
+		indexName     types.String
+		namePrefix    types.String
+		expectedError string
+	}{
+		{name: "index name", indexName: types.StringValue("test-index"), namePrefix: types.StringNull()},
+		{name: "name prefix", indexName: types.StringNull(), namePrefix: types.StringValue("test-")},
+		{name: "neither", indexName: types.StringNull(), namePrefix: types.StringNull(), expectedError: "Missing Attribute Configuration"},
+		{name: "both", indexName: types.StringValue("test-index"), namePrefix: types.StringValue("test-"), expectedError: "Conflicting Attribute Configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			model := testIndexModel("READY")
+			model.IndexName = tt.indexName
+			model.NamePrefix = tt.namePrefix
+			configState := newTestIndexState(t, model)
+
+			resp := &resource.ValidateConfigResponse{}
+			(&indicesResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: configState.Schema, Raw: configState.Raw},
+			}, resp)
+
+			if got := diagnosticSummaries(resp.Diagnostics.Errors()); got != tt.expectedError {
+				t.Errorf("expected error %q, got %q", tt.expectedError, got)
+			}
+		})
+	}
+}
+
+func TestValidateConfigExtraSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		extraSettings string
+		expectedError string
+	}{
+		{name: "unsupported setting", extraSettings: `{"textChunkPrefix": "passage: "}`},
+		{name: "not an object", extraSettings: `["a"]`, expectedError: "Invalid Extra Settings JSON"},
+		{
+			name:          "typed setting",
+			extraSettings: `{"textChunkPrefix": "passage: ", "annParameters": {"parameters": {"m": 32}}}`,
+			expectedError: "Conflicting Extra Settings JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			model := testIndexModel("READY")
+			model.Settings.ExtraSettingsJSON = jsontypes.NewNormalizedValue(tt.extraSettings)
+			configState := newTestIndexState(t, model)
+
+			resp := &resource.ValidateConfigResponse{}
+			(&indicesResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: configState.Schema, Raw: configState.Raw},
+			}, resp)
+
+			if got := diagnosticSummaries(resp.Diagnostics.Errors()); got != tt.expectedError {
+				t.Errorf("expected error %q, got %q", tt.expectedError, got)
+			}
+			if tt.expectedError == "Conflicting Extra Settings JSON" &&
+				!strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "settings.ann_parameters") {
+				t.Errorf("expected the error to name settings.ann_parameters, got %s", resp.Diagnostics.Errors()[0].Detail())
+			}
+		})
+	}
+}
+
+func TestGenerateIndexName(t *testing.T) {
+	first, err := generateIndexName("blue-green-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := generateIndexName("blue-green-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(first, "blue-green-") || first == second {
+		t.Errorf("expected distinct names with the prefix, got %s and %s", first, second)
+	}
+}
+
+func TestReadPendingIndex(t *testing.T) {
+	live := go_marqo.IndexDetail{
+		IndexName:          "test-index",
+		IndexStatus:        "READY",
+		Type:               "unstructured",
+		Model:              "open_clip/ViT-L-14/laion2b_s32b_b82k",
+		InferenceType:      "CPU.LARGE",
+		NumberOfInferences: 1,
+		StorageClass:       "BASIC",
+		NumberOfShards:     1,
+		MarqoEndpoint:      "https://test-index.marqo.ai",
+	}
+
+	tests := []struct {
+		name            string
+		status          string
+		indices         []go_marqo.IndexDetail
+		expectedRemoved bool
+	}{
+		{name: "creating index deleted outside terraform", status: "CREATING", expectedRemoved: true},
+		{name: "modifying index deleted outside terraform", status: "MODIFYING", expectedRemoved: true},
+		{name: "creating index that became ready", status: "CREATING", indices: []go_marqo.IndexDetail{live}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			r := &indicesResource{marqoClient: newTestIndexServer(t, tt.indices...)}
+			state := newTestIndexState(t, testIndexModel(tt.status))
+
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if ctx.Err() != nil {
+				t.Fatal("expected the read not to wait for the index")
+			}
+			if removed := resp.State.Raw.IsNull(); removed != tt.expectedRemoved {
+				t.Fatalf("expected removed %t, got %t", tt.expectedRemoved, removed)
+			}
+			if !tt.expectedRemoved {
+				var status types.String
+				resp.State.GetAttribute(ctx, path.Root("index_status"), &status)
+				if status.ValueString() != "READY" {
+					t.Errorf("expected status READY, got %s", status)
+				}
+			}
+		})
+	}
+}
+
+func TestPendingStatusTimeout(t *testing.T) {
+	ctx := context.Background()
+	model := testIndexModel("CREATING")
+	model.Timeouts = &timeouts{Create: types.StringValue("10m"), Update: types.StringValue("45m"), Delete: types.StringNull()}
+	if got := pendingStatusTimeout(ctx, *model); got != 10*time.Minute {
+		t.Errorf("expected the create timeout for a creating index, got %v", got)
+	}
+
+	model.IndexStatus = types.StringValue("MODIFYING")
+	if got := pendingStatusTimeout(ctx, *model); got != 45*time.Minute {
+		t.Errorf("expected the update timeout for a modifying index, got %v", got)
+	}
+
+	model.Timeouts = nil
+	if got := pendingStatusTimeout(ctx, *model); got != 30*time.Minute {
+		t.Errorf("expected the default timeout, got %v", got)
+	}
+}
+
+func TestExampleIndexConfig(t *testing.T) {
+	for _, example := range indexExamples {
+		config, err := exampleIndexConfig(example, "renamed-index")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(config, `resource "marqo_index" "test" {`) || !strings.Contains(config, `index_name = "renamed-index"`) ||
+			!strings.HasSuffix(config, "}") {
+			t.Errorf("unexpected config for example %s:\n%s", example, config)
+		}
+	}
+}
+
+func TestPlanReplacementOfLiveIndex(t *testing.T) {
+	live := go_marqo.IndexDetail{
+		IndexName:          "test-index",
+		IndexStatus:        "FAILED",
+		Type:               "unstructured",
+		Model:              "open_clip/ViT-L-14/laion2b_s32b_b82k",
+		InferenceType:      "CPU.LARGE",
+		NumberOfInferences: 1,
+		StorageClass:       "BASIC",
+		NumberOfShards:     2,
+	}
+	ownedPrivate, err := json.Marshal(map[string][]byte{ownedIndexKey: ownedIndexValue("test-index")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		private       []byte
+		adoptExisting bool
+		expectedError string
+	}{
+		{name: "new index over an index outside of state", expectedError: "Index Already Exists"},
+		{name: "replacement of the managed index", private: ownedPrivate},
+		{name: "replacement of the managed index with adopt_existing", private: ownedPrivate, adoptExisting: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testProtocolServer(t, newTestIndexServer(t, live).BaseURL)
+
+			// The create half of a replacement is planned with a null prior state
+			model := testIndexModel("")
+			model.IndexStatus = types.StringNull()
+			model.MarqoEndpoint = types.StringNull()
+			model.DiscoveredFields = types.SetNull(discoveredFieldObjectType)
+			model.AdoptExisting = types.BoolValue(tt.adoptExisting)
+			config := newTestIndexState(t, model)
+			prior := tfsdk.State{Schema: config.Schema, Raw: tftypes.NewValue(config.Raw.Type(), nil)}
+
+			resp, _ := testPlanResourceChange(t, server, "marqo_index", prior, config, config, tt.private)
+			var errors []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					errors = append(errors, d.Summary)
+				}
+			}
+			if got := strings.Join(errors, "; "); got != tt.expectedError {
+				t.Errorf("expected error %q, got %q", tt.expectedError, got)
+			}
+		})
+	}
+}
+
+func TestReadRecordsOwnedIndex(t *testing.T) {
+	live := go_marqo.IndexDetail{IndexName: "test-index", IndexStatus: "READY", Type: "unstructured", NumberOfShards: 1}
+	server := testProtocolServer(t, newTestIndexServer(t, live).BaseURL)
+	state := newTestIndexState(t, testIndexModel("READY"))
+
+	resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     "marqo_index",
+		CurrentState: testDynamicValue(t, state.Raw),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var private map[string][]byte
+	if err := json.Unmarshal(resp.Private, &private); err != nil {
+		t.Fatalf("failed to decode private state %q: %v", resp.Private, err)
+	}
+	if string(private[ownedIndexKey]) != `"test-index"` {
+		t.Errorf("expected the index to be recorded in private state, got %q", private[ownedIndexKey])
+	}
+}
+
+func TestPlanKeepsIndexStatus(t *testing.T) {
+	live := go_marqo.IndexDetail{
+		IndexName:          "test-index",
+		IndexStatus:        "READY",
+		Type:               "unstructured",
+		Model:              "open_clip/ViT-L-14/laion2b_s32b_b82k",
+		InferenceType:      "CPU.LARGE",
+		NumberOfInferences: 1,
+		StorageClass:       "BASIC",
+		NumberOfShards:     1,
+	}
+	server := testProtocolServer(t, newTestIndexServer(t, live).BaseURL)
+
+	tests := []struct {
+		name            string
+		change          func(model *IndexResourceModel)
+		expectedUnknown bool
+	}{
+		{
+			name: "timeouts changed",
+			change: func(model *IndexResourceModel) {
+				model.Timeouts = &timeouts{Create: types.StringNull(), Update: types.StringValue("45m"), Delete: types.StringNull()}
+			},
+		},
+		{
+			name:            "replicas added",
+			change:          func(model *IndexResourceModel) { model.Settings.NumberOfReplicas = types.Int64Value(1) },
+			expectedUnknown: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := testIndexModel("READY")
+			configFormIndexSettings(&model.Settings)
+			prior := newTestIndexState(t, model)
+
+			// Terraform proposes the configured values, keeping the prior values of computed attributes
+			tt.change(model)
+			proposed := newTestIndexState(t, model)
+			model.MarqoEndpoint = types.StringNull()
+			model.IndexStatus = types.StringNull()
+			model.DiscoveredFields = types.SetNull(discoveredFieldObjectType)
+			config := newTestIndexState(t, model)
+
+			resp, planned := testPlanResourceChange(t, server, "marqo_index", prior, config, proposed, nil)
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+				}
+			}
+
+			status, _, err := tftypes.WalkAttributePath(planned, tftypes.NewAttributePath().WithAttributeName("index_status"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if known := status.(tftypes.Value).IsKnown(); known == tt.expectedUnknown {
+				t.Errorf("expected index_status unknown %t, got %s", tt.expectedUnknown, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ resource.ResourceWithUpgradeState = &indicesResource{}
+
+// indexResourceSchemaVersion is the current version of the marqo_index schema. Bump it and add a
+// step to indexStateUpgradeSteps whenever a schema change would not decode from older states.
+const indexResourceSchemaVersion = 1
+
+// indexStateUpgradeSteps upgrade raw marqo_index state by a single schema version,
+// keyed by the version being upgraded from.
+var indexStateUpgradeSteps = map[int64]func(state map[string]interface{}){
+	0: upgradeIndexStateV0,
+}
+
+// UpgradeState returns an upgrader for every prior schema version. Each upgrader applies
+// the single-version steps in order until the state matches the current schema.
+func (r *indicesResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, indexResourceSchemaVersion)
+	for version := int64(0); version < indexResourceSchemaVersion; version++ {
+		fromVersion := version
+		upgraders[fromVersion] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeIndexState(ctx, fromVersion, req, resp)
+			},
+		}
+	}
+	return upgraders
+}
+
+// upgradeIndexState decodes the raw JSON state, runs the upgrade steps from fromVersion to the
+// current version and decodes the result against the current schema.
+func upgradeIndexState(ctx context.Context, fromVersion int64, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("The marqo_index state at schema version %d has no JSON data to upgrade. Please report this issue to the provider developers.", fromVersion),
+		)
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(req.RawState.JSON))
+	decoder.UseNumber()
+
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Could not decode marqo_index state at schema version %d: %s", fromVersion, err),
+		)
+		return
+	}
+
+	for version := fromVersion; version < indexResourceSchemaVersion; version++ {
+		indexStateUpgradeSteps[version](state)
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Could not encode upgraded marqo_index state: %s", err),
+		)
+		return
+	}
+
+	// Attributes removed from the schema are dropped rather than failing the upgrade
+	value, err := tftypes.ValueFromJSONWithOpts(upgraded, resp.State.Schema.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{
+		IgnoreUndefinedAttributes: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Upgraded marqo_index state does not match the current schema: %s", err),
+		)
+		return
+	}
+
+	resp.State.Raw = value
+}
+
+// upgradeIndexStateV0 upgrades states written before schema versioning was introduced. Inference type
+// and storage class aliases are rewritten to their configuration form, and the placeholder values left
+// by early imports and interrupted creates are cleared so that the next refresh fills them in.
+func upgradeIndexStateV0(state map[string]interface{}) {
+	if endpoint, ok := state["marqo_endpoint"].(string); ok && (endpoint == "" || endpoint == "pending") {
+		state["marqo_endpoint"] = nil
+	}
+
+	settings, ok := state["settings"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if inferenceType, ok := settings["inference_type"].(string); ok {
+		if mappedValue, exists := inferenceTypeMap[inferenceType]; exists {
+			settings["inference_type"] = mappedValue
+		}
+	}
+	if storageClass, ok := settings["storage_class"].(string); ok {
+		if mappedValue, exists := storageClassMap[storageClass]; exists {
+			settings["storage_class"] = mappedValue
+		}
+	}
+
+	// Imports used to write an empty type along with zero values for every required setting
+	if indexType, ok := settings["type"].(string); ok && indexType == "" {
+		for _, key := range []string{"type", "model", "inference_type", "storage_class",
+			"number_of_inferences", "number_of_shards", "number_of_replicas"} {
+			settings[key] = nil
+		}
+	}
+}
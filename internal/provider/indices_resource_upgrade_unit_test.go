@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeTestState runs the upgrader for a prior schema version against a fixture state
+// and returns the upgraded model.
+func upgradeTestState(t *testing.T, version int64, fixture string) IndexResourceModel {
+	t.Helper()
+	ctx := context.Background()
+
+	rawState, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &indicesResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader for schema version %d", version)
+	}
+
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: rawState}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected upgrade error: %v", resp.Diagnostics)
+	}
+
+	var model IndexResourceModel
+	if diags := resp.State.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("upgraded state does not decode: %v", diags)
+	}
+	return model
+}
+
+func TestIndexStateUpgradeV0(t *testing.T) {
+	t.Run("release 1.0 state with API aliases", func(t *testing.T) {
+		model := upgradeTestState(t, 0, "index_state_v0_release_1_0.json")
+
+		if model.IndexName.ValueString() != "products" {
+			t.Errorf("expected index name products, got %s", model.IndexName.ValueString())
+		}
+		if model.Settings.InferenceType.ValueString() != "marqo.CPU.small" {
+			t.Errorf("expected inference type marqo.CPU.small, got %s", model.Settings.InferenceType.ValueString())
+		}
+		if model.Settings.StorageClass.ValueString() != "marqo.basic" {
+			t.Errorf("expected storage class marqo.basic, got %s", model.Settings.StorageClass.ValueString())
+		}
+		if model.Settings.AnnParameters == nil || model.Settings.AnnParameters.Parameters.M.ValueInt64() != 16 {
+			t.Errorf("expected ann_parameters to be preserved, got %+v", model.Settings.AnnParameters)
+		}
+		if !model.Settings.TreatUrlsAndPointersAsMedia.IsNull() {
+			t.Errorf("expected treat_urls_and_pointers_as_media to be null, got %s", model.Settings.TreatUrlsAndPointersAsMedia)
+		}
+	})
+
+	t.Run("release 1.2 structured state", func(t *testing.T) {
+		model := upgradeTestState(t, 0, "index_state_v0_release_1_2.json")
+
+		if !model.MarqoEndpoint.IsNull() {
+			t.Errorf("expected pending endpoint to be cleared, got %s", model.MarqoEndpoint)
+		}
+		if len(model.Settings.AllFields) != 2 {
+			t.Fatalf("expected 2 fields, got %d", len(model.Settings.AllFields))
+		}
+		if model.Settings.VideoPreprocessing == nil || model.Settings.VideoPreprocessing.SplitLength.ValueInt64() != 20 {
+			t.Errorf("expected video_preprocessing to be preserved, got %+v", model.Settings.VideoPreprocessing)
+		}
+		if model.Timeouts == nil || model.Timeouts.Create.ValueString() != "45m" {
+			t.Errorf("expected timeouts to be preserved, got %+v", model.Timeouts)
+		}
+	})
+
+	t.Run("import placeholder state", func(t *testing.T) {
+		model := upgradeTestState(t, 0, "index_state_v0_import_placeholder.json")
+
+		if !model.Settings.Type.IsNull() || !model.Settings.NumberOfShards.IsNull() || !model.Settings.Model.IsNull() {
+			t.Errorf("expected placeholder settings to be cleared, got %+v", model.Settings)
+		}
+	})
+}
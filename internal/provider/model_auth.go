@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ModelAuthModel maps the provider model_auth block.
+type ModelAuthModel struct {
+	S3 *ModelAuthS3Model `tfsdk:"s3"`
+	HF *ModelAuthHFModel `tfsdk:"hf"`
+}
+
+type ModelAuthS3Model struct {
+	AWSAccessKeyID     types.String `tfsdk:"aws_access_key_id"`
+	AWSSecretAccessKey types.String `tfsdk:"aws_secret_access_key"`
+}
+
+type ModelAuthHFModel struct {
+	Token types.String `tfsdk:"token"`
+}
+
+// modelAuthSchema returns the schema of the provider model_auth block. The credentials are
+// configured on the provider rather than on marqo_document and marqo_documents, because provider
+// configuration is never written to state, while resource and data source attributes always are
+// with the plugin framework version in use. The cost is that every document resource of a provider
+// configuration shares one set of credentials, so models that need different credentials are
+// managed through provider aliases.
+func modelAuthSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:  true,
+		Sensitive: true,
+		Description: "Credentials for custom models with model_properties.model_location.auth_required = true. They are " +
+			"sent as modelAuth when marqo_document and marqo_documents add documents, are never written to state, " +
+			"and are redacted from logs. Set exactly one of s3 or hf. The credentials are set on the provider rather " +
+			"than on the document resources so that they stay out of state, which means every document resource using " +
+			"a provider configuration sends the same credentials: when models need different credentials, declare a " +
+			"provider configuration with an alias for each and set provider on the document resources. The values must " +
+			"be known when the provider is configured, so they cannot come from resources created in the same apply.",
+		Attributes: map[string]schema.Attribute{
+			"s3": schema.SingleNestedAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "AWS credentials for a model in a private S3 bucket.",
+				Attributes: map[string]schema.Attribute{
+					"aws_access_key_id": schema.StringAttribute{
+						Required:  true,
+						Sensitive: true,
+					},
+					"aws_secret_access_key": schema.StringAttribute{
+						Required:  true,
+						Sensitive: true,
+					},
+				},
+			},
+			"hf": schema.SingleNestedAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A Hugging Face token for a model in a private repository.",
+				Attributes: map[string]schema.Attribute{
+					"token": schema.StringAttribute{
+						Required:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// modelAuthFromConfig converts the model_auth provider configuration, adding an error if it is
+// not known yet or is incomplete.
+func modelAuthFromConfig(ctx context.Context, value types.Object, diagnostics *diag.Diagnostics) *go_marqo.ModelAuth {
+	if value.IsNull() {
+		return nil
+	}
+
+	// Credentials that are only known after apply would be sent as empty strings
+	terraformValue, err := value.ToTerraformValue(ctx)
+	if err != nil || !terraformValue.IsFullyKnown() {
+		diagnostics.AddAttributeError(
+			path.Root("model_auth"),
+			"Unknown Model Auth",
+			"The provider cannot send model credentials as there is an unknown configuration value in model_auth. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil
+	}
+
+	var model ModelAuthModel
+	diagnostics.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
+	}
+	return model.toModelAuth(diagnostics)
+}
+
+// toModelAuth converts the model_auth block, adding an error if it is incomplete.
+func (m *ModelAuthModel) toModelAuth(diagnostics *diag.Diagnostics) *go_marqo.ModelAuth {
+	if m == nil {
+		return nil
+	}
+	if (m.S3 == nil) == (m.HF == nil) {
+		diagnostics.AddAttributeError(
+			path.Root("model_auth"),
+			"Invalid Model Auth",
+			"Exactly one of model_auth.s3 or model_auth.hf must be set.")
+		return nil
+	}
+
+	auth := &go_marqo.ModelAuth{}
+	if m.S3 != nil {
+		auth.S3 = &go_marqo.ModelAuthS3{
+			AWSAccessKeyID:     m.S3.AWSAccessKeyID.ValueString(),
+			AWSSecretAccessKey: m.S3.AWSSecretAccessKey.ValueString(),
+		}
+	}
+	if m.HF != nil {
+		auth.HF = &go_marqo.ModelAuthHF{Token: m.HF.Token.ValueString()}
+	}
+	return auth
+}
+
+// maskModelAuth returns a context whose log entries have the model auth credentials redacted.
+func maskModelAuth(ctx context.Context, auth *go_marqo.ModelAuth) context.Context {
+	if auth == nil {
+		return ctx
+	}
+
+	var credentials []string
+	if auth.S3 != nil {
+		credentials = append(credentials, auth.S3.AWSAccessKeyID, auth.S3.AWSSecretAccessKey)
+	}
+	if auth.HF != nil {
+		credentials = append(credentials, auth.HF.Token)
+	}
+
+	// An empty string would mask every message
+	var secrets []string
+	for _, credential := range credentials {
+		if credential != "" {
+			secrets = append(secrets, credential)
+		}
+	}
+
+	ctx = tflog.MaskMessageStrings(ctx, secrets...)
+	return tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+}
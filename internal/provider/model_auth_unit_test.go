@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"marqo/go_marqo"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestModelAuthToModelAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		model         *ModelAuthModel
+		expectedError bool
+		expectedAuth  bool
+	}{
+		{name: "not set"},
+		{
+			name:         "hugging face",
+			model:        &ModelAuthModel{HF: &ModelAuthHFModel{Token: types.StringValue("hf_secret")}},
+			expectedAuth: true,
+		},
+		{
+			name: "s3",
+			model: &ModelAuthModel{S3: &ModelAuthS3Model{
+				AWSAccessKeyID:     types.StringValue("AKIAEXAMPLE"),
+				AWSSecretAccessKey: types.StringValue("aws_secret"),
+			}},
+			expectedAuth: true,
+		},
+		{name: "neither", model: &ModelAuthModel{}, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			auth := tt.model.toModelAuth(&diags)
+			if diags.HasError() != tt.expectedError {
+				t.Errorf("expected error %t, got %v", tt.expectedError, diags)
+			}
+			if (auth != nil) != tt.expectedAuth {
+				t.Errorf("expected auth %t, got %v", tt.expectedAuth, auth)
+			}
+		})
+	}
+}
+
+func TestMaskModelAuth(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	auth := &go_marqo.ModelAuth{HF: &go_marqo.ModelAuthHF{Token: "hf_secret"}}
+	ctx = maskModelAuth(ctx, auth)
+	tflog.Info(ctx, "adding documents with token hf_secret", map[string]interface{}{"token": "hf_secret"})
+	tflog.Info(ctx, fmt.Sprintf("request: %+v", go_marqo.AddDocumentsRequest{ModelAuth: auth}))
+
+	if !strings.Contains(output.String(), "adding documents") {
+		t.Fatalf("expected log output, got %s", output.String())
+	}
+	if strings.Contains(output.String(), "hf_secret") {
+		t.Errorf("expected token to be redacted, got %s", output.String())
+	}
+}
+
+func TestConfigureUnknownModelAuth(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &provider.SchemaResponse{}
+	New("test")().Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	modelAuthType := schemaResp.Schema.Attributes["model_auth"].GetType().TerraformType(ctx).(tftypes.Object)
+	hfType := modelAuthType.AttributeTypes["hf"].(tftypes.Object)
+	s3Type := modelAuthType.AttributeTypes["s3"]
+
+	hf := func(token tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(modelAuthType, map[string]tftypes.Value{
+			"hf": tftypes.NewValue(hfType, map[string]tftypes.Value{"token": token}),
+			"s3": tftypes.NewValue(s3Type, nil),
+		})
+	}
+
+	tests := []struct {
+		name          string
+		modelAuth     tftypes.Value
+		expectedError string
+	}{
+		{name: "not set", modelAuth: tftypes.NewValue(modelAuthType, nil)},
+		{name: "known", modelAuth: hf(tftypes.NewValue(tftypes.String, "hf_secret"))},
+		{name: "unknown", modelAuth: tftypes.NewValue(modelAuthType, tftypes.UnknownValue), expectedError: "Unknown Model Auth"},
+		{name: "unknown token", modelAuth: hf(tftypes.NewValue(tftypes.String, tftypes.UnknownValue)), expectedError: "Unknown Model Auth"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			for name, value := range map[string]string{"host": "https://api.marqo.ai", "api_key": "test-key"} {
+				if diags := config.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+					t.Fatalf("failed to build provider config: %v", diags)
+				}
+			}
+			raw, err := tftypes.Transform(config.Raw, func(attributePath *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName("model_auth")) {
+					return tt.modelAuth, nil
+				}
+				return value, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			server, err := providerserver.NewProtocol6WithError(New("test")())()
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: testDynamicValue(t, raw)})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var summaries []string
+			for _, d := range resp.Diagnostics {
+				summaries = append(summaries, d.Summary)
+			}
+			if got := strings.Join(summaries, ", "); got != tt.expectedError {
+				t.Errorf("expected diagnostics %q, got %q", tt.expectedError, got)
+			}
+		})
+	}
+}
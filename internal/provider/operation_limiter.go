@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultMaxConcurrentOperations is used when max_concurrent_operations is not configured.
+const defaultMaxConcurrentOperations = 5
+
+// operationLimiter queues mutating index operations so that at most a fixed number run
+// against the account at once, and at most one runs against any single index.
+type operationLimiter struct {
+	slots chan struct{}
+
+	mu      sync.Mutex
+	indexes map[string]chan struct{}
+}
+
+// newOperationLimiter creates a limiter allowing maxConcurrent operations at a time.
+func newOperationLimiter(maxConcurrent int64) *operationLimiter {
+	return &operationLimiter{
+		slots:   make(chan struct{}, maxConcurrent),
+		indexes: make(map[string]chan struct{}),
+	}
+}
+
+// indexLock returns the lock channel for an index, creating it on first use.
+func (l *operationLimiter) indexLock(indexName string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, exists := l.indexes[indexName]
+	if !exists {
+		lock = make(chan struct{}, 1)
+		l.indexes[indexName] = lock
+	}
+	return lock
+}
+
+// acquire blocks until the operation is allowed to run and returns a function that releases it.
+// A nil limiter never blocks, which keeps resources usable without a configured provider.
+func (l *operationLimiter) acquire(ctx context.Context, operation string, indexName string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+
+	// Take the index lock first so queued operations on a busy index do not hold an account slot
+	lock := l.indexLock(indexName)
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("cancelled while waiting for another operation on index %s: %w", indexName, ctx.Err())
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		<-lock
+		return nil, fmt.Errorf("cancelled while waiting for a free operation slot for index %s: %w", indexName, ctx.Err())
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Starting %s of index %s after waiting %v", operation, indexName, time.Since(start)), map[string]any{
+		"operation":  operation,
+		"index_name": indexName,
+		"wait_time":  time.Since(start).String(),
+	})
+
+	return func() {
+		<-l.slots
+		<-lock
+	}, nil
+}
+
+// runBounded calls run for each index from 0 to n-1, running up to workers calls at a time,
+// and returns once every call has finished.
+func runBounded(n int, workers int, run func(i int)) {
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < min(workers, n); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				run(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOperationLimiter(t *testing.T) {
+	t.Run("limits concurrent operations", func(t *testing.T) {
+		limiter := newOperationLimiter(2)
+
+		var running, maxRunning int32
+		var wg sync.WaitGroup
+		for _, indexName := range []string{"a", "b", "c", "d", "e"} {
+			wg.Add(1)
+			go func(indexName string) {
+				defer wg.Done()
+				release, err := limiter.acquire(context.Background(), "create", indexName)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				defer release()
+
+				current := atomic.AddInt32(&running, 1)
+				for {
+					observed := atomic.LoadInt32(&maxRunning)
+					if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			}(indexName)
+		}
+		wg.Wait()
+
+		if maxRunning > 2 {
+			t.Errorf("expected at most 2 concurrent operations, got %d", maxRunning)
+		}
+	})
+
+	t.Run("serializes operations on the same index", func(t *testing.T) {
+		limiter := newOperationLimiter(5)
+
+		release, err := limiter.acquire(context.Background(), "update", "same")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := limiter.acquire(ctx, "delete", "same"); err == nil {
+			t.Fatal("expected second operation on the same index to wait until cancelled")
+		}
+
+		release()
+		secondRelease, err := limiter.acquire(context.Background(), "delete", "same")
+		if err != nil {
+			t.Fatal(err)
+		}
+		secondRelease()
+	})
+
+	t.Run("nil limiter does not block", func(t *testing.T) {
+		var limiter *operationLimiter
+		release, err := limiter.acquire(context.Background(), "create", "any")
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	})
+}
+
+func TestRunBounded(t *testing.T) {
+	var running, maxRunning int32
+	var calls [7]int32
+	runBounded(len(calls), 3, func(i int) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&calls[i], 1)
+		atomic.AddInt32(&running, -1)
+	})
+
+	for i, count := range calls {
+		if count != 1 {
+			t.Errorf("expected %d to be run once, got %d", i, count)
+		}
+	}
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", maxRunning)
+	}
+
+	// No work starts no workers
+	runBounded(0, 3, func(i int) { t.Errorf("unexpected call %d", i) })
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -16,13 +17,25 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &marqoProvider{}
+	_ provider.Provider                       = &marqoProvider{}
+	_ provider.ProviderWithEphemeralResources = &marqoProvider{}
 )
 
 // marqoProviderModel maps provider schema data to a Go type.
 type marqoProviderModel struct {
-	Host   types.String `tfsdk:"host"`
-	APIKey types.String `tfsdk:"api_key"`
+	Host                    types.String `tfsdk:"host"`
+	APIKey                  types.String `tfsdk:"api_key"`
+	MaxConcurrentOperations types.Int64  `tfsdk:"max_concurrent_operations"`
+	PricingFile             types.String `tfsdk:"pricing_file"`
+	ModelAuth               types.Object `tfsdk:"model_auth"`
+}
+
+// marqoResourceData is passed to resources and ephemeral resources when the provider is configured.
+type marqoResourceData struct {
+	client    *go_marqo.Client
+	limiter   *operationLimiter
+	pricing   *pricingTable
+	modelAuth *go_marqo.ModelAuth
 }
 
 // marqoProvider is the provider implementation.
@@ -61,6 +74,18 @@ func (p *marqoProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Sensitive:   true,
 				Description: "The Marqo API key. Can be set with MARQO_API_KEY environment variable.",
 			},
+			"max_concurrent_operations": schema.Int64Attribute{
+				Optional: true,
+				Description: "The maximum number of index create, update and delete operations run against the account at once. " +
+					"Operations on the same index always run one at a time. Default is 5.",
+			},
+			"pricing_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path to a local JSON file with hourly prices used for the cost estimates in index scaling plan warnings, " +
+					"in the form {\"inference_types\": {\"marqo.GPU\": 1.0}, \"storage_classes\": {\"marqo.basic\": 0.03}}. " +
+					"Prices that are not listed keep their approximate defaults.",
+			},
+			"model_auth": modelAuthSchema(),
 		},
 	}
 }
@@ -129,6 +154,33 @@ func (p *marqoProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	// An unknown limit only shapes how this run schedules work, so the default is used until it is known
+	maxConcurrentOperations := int64(defaultMaxConcurrentOperations)
+	if config.MaxConcurrentOperations.IsUnknown() {
+		tflog.Debug(ctx, "max_concurrent_operations is unknown, using the default", map[string]any{"default": defaultMaxConcurrentOperations})
+	} else if !config.MaxConcurrentOperations.IsNull() {
+		maxConcurrentOperations = config.MaxConcurrentOperations.ValueInt64()
+	}
+
+	if maxConcurrentOperations < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_operations"),
+			"Invalid Maximum Concurrent Operations",
+			"The max_concurrent_operations value must be at least 1.",
+		)
+	}
+
+	pricing, err := loadPricingTable(config.PricingFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pricing_file"),
+			"Invalid Pricing File",
+			"The provider cannot load the pricing file: "+err.Error(),
+		)
+	}
+
+	modelAuth := modelAuthFromConfig(ctx, config.ModelAuth, &resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -150,8 +202,15 @@ func (p *marqoProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	resourceData := &marqoResourceData{
+		client:    client,
+		limiter:   newOperationLimiter(maxConcurrentOperations),
+		pricing:   pricing,
+		modelAuth: modelAuth,
+	}
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = resourceData
+	resp.EphemeralResourceData = resourceData
 
 	tflog.Info(ctx, "Configured Marqo client", map[string]any{"success": true})
 }
@@ -160,6 +219,9 @@ func (p *marqoProvider) Configure(ctx context.Context, req provider.ConfigureReq
 func (p *marqoProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		ReadIndicesDataSource,
+		ReadIndexDataSource,
+		ReadIndexStatsDataSource,
+		ReadIndexSettingsDataSource,
 	}
 }
 
@@ -167,5 +229,15 @@ func (p *marqoProvider) DataSources(_ context.Context) []func() datasource.DataS
 func (p *marqoProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		ManageIndicesResource,
+		ManageDocumentResource,
+		ManageDocumentsResource,
+		ManageAPIKeyResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *marqoProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		OpenEphemeralAPIKey,
 	}
 }
@@ -183,3 +183,32 @@ func testAccCheckIndexIsReady(name string) resource.TestCheckFunc {
 		}
 	}
 }
+
+// testAccCreateIndexOutsideTerraform creates a minimal unstructured index directly through the API,
+// simulating an index made in the console or lost from state.
+func testAccCreateIndexOutsideTerraform(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		host := os.Getenv("MARQO_HOST")
+		apiKey := os.Getenv("MARQO_API_KEY")
+
+		client, err := go_marqo.NewClient(&host, &apiKey)
+		if err != nil {
+			return fmt.Errorf("Error creating Marqo client: %s", err)
+		}
+
+		fmt.Printf("Creating index %s outside of Terraform...\n", name)
+		err = client.CreateIndex(name, map[string]interface{}{
+			"type":               "unstructured",
+			"model":              "open_clip/ViT-L-14/laion2b_s32b_b82k",
+			"inferenceType":      "marqo.CPU.large",
+			"numberOfInferences": 1,
+			"numberOfReplicas":   0,
+			"numberOfShards":     1,
+			"storageClass":       "marqo.basic",
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating index %s: %s", name, err)
+		}
+		return nil
+	}
+}
@@ -2,9 +2,15 @@ package provider
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestProviderUnit(t *testing.T) {
@@ -25,6 +31,14 @@ func TestProviderUnit(t *testing.T) {
 		if _, ok := schemaResp.Schema.Attributes["api_key"]; !ok {
 			t.Fatal("Schema should have 'api_key' attribute")
 		}
+
+		if _, ok := schemaResp.Schema.Attributes["max_concurrent_operations"]; !ok {
+			t.Fatal("Schema should have 'max_concurrent_operations' attribute")
+		}
+
+		if _, ok := schemaResp.Schema.Attributes["pricing_file"]; !ok {
+			t.Fatal("Schema should have 'pricing_file' attribute")
+		}
 	})
 
 	t.Run("resources", func(t *testing.T) {
@@ -41,3 +55,139 @@ func TestProviderUnit(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigureMaxConcurrentOperations(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &provider.SchemaResponse{}
+	New("test")().Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		name          string
+		value         tftypes.Value
+		expectedError string
+	}{
+		{name: "not set", value: tftypes.NewValue(tftypes.Number, nil)},
+		{name: "set", value: tftypes.NewValue(tftypes.Number, 3)},
+		{name: "unknown", value: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+		{name: "zero", value: tftypes.NewValue(tftypes.Number, 0), expectedError: "Invalid Maximum Concurrent Operations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			for name, value := range map[string]string{"host": "https://api.marqo.ai", "api_key": "test-key"} {
+				if diags := config.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+					t.Fatalf("failed to build provider config: %v", diags)
+				}
+			}
+			raw, err := tftypes.Transform(config.Raw, func(attributePath *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+				if attributePath.Equal(tftypes.NewAttributePath().WithAttributeName("max_concurrent_operations")) {
+					return tt.value, nil
+				}
+				return value, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			server, err := providerserver.NewProtocol6WithError(New("test")())()
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: testDynamicValue(t, raw)})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var summaries []string
+			for _, d := range resp.Diagnostics {
+				summaries = append(summaries, d.Summary)
+			}
+			if got := strings.Join(summaries, ", "); got != tt.expectedError {
+				t.Errorf("expected diagnostics %q, got %q", tt.expectedError, got)
+			}
+		})
+	}
+}
+
+// testProtocolServer returns the provider as Terraform drives it, configured to use host.
+func testProtocolServer(t *testing.T, host string) tfprotov6.ProviderServer {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+
+	schemaResp := &provider.SchemaResponse{}
+	New("test")().Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	for name, value := range map[string]string{"host": host, "api_key": "test-key"} {
+		if diags := config.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("failed to build provider config: %v", diags)
+		}
+	}
+
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: testDynamicValue(t, config.Raw)})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("failed to configure provider: %v %v", err, resp.Diagnostics)
+	}
+	return server
+}
+
+// testDynamicValue encodes a value for a protocol request.
+func testDynamicValue(t *testing.T, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	dynamicValue, err := tfprotov6.NewDynamicValue(value.Type(), value)
+	if err != nil {
+		t.Fatalf("failed to encode value: %v", err)
+	}
+	return &dynamicValue
+}
+
+// testPlanResourceChange plans a resource change the way Terraform core does, and fails the test
+// if the planned value of an attribute that is not computed differs from its configured value,
+// which Terraform rejects as an invalid plan.
+func testPlanResourceChange(t *testing.T, server tfprotov6.ProviderServer, typeName string, prior tfsdk.State, config tfsdk.State, proposed tfsdk.State, priorPrivate []byte) (*tfprotov6.PlanResourceChangeResponse, tftypes.Value) {
+	t.Helper()
+	ctx := context.Background()
+
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       testDynamicValue(t, prior.Raw),
+		Config:           testDynamicValue(t, config.Raw),
+		ProposedNewState: testDynamicValue(t, proposed.Raw),
+		PriorPrivate:     priorPrivate,
+	})
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+	if resp.PlannedState == nil {
+		return resp, tftypes.Value{}
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(config.Raw.Type())
+	if err != nil {
+		t.Fatalf("failed to decode planned state: %v", err)
+	}
+
+	err = tftypes.Walk(config.Raw, func(attributePath *tftypes.AttributePath, configValue tftypes.Value) (bool, error) {
+		schemaAttribute, err := config.Schema.AttributeAtTerraformPath(ctx, attributePath)
+		if err != nil || schemaAttribute.IsComputed() || configValue.Type().Is(tftypes.Object{}) {
+			return true, nil
+		}
+		plannedValue, _, err := tftypes.WalkAttributePath(planned, attributePath)
+		if err != nil {
+			return false, err
+		}
+		if !configValue.Equal(plannedValue.(tftypes.Value)) {
+			t.Errorf("invalid plan: %s is planned as %s but configured as %s", attributePath, plannedValue, configValue)
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to compare planned state: %v", err)
+	}
+	return resp, planned
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"marqo/go_marqo"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+)
+
+// pricingTable holds hourly prices in USD used to estimate the cost of scaling an index.
+// Inference types are priced per inference node and storage classes per shard copy.
+type pricingTable struct {
+	InferenceTypes map[string]float64 `json:"inference_types"`
+	StorageClasses map[string]float64 `json:"storage_classes"`
+}
+
+// defaultPricingTable returns approximate Marqo Cloud list prices. They are only used for
+// relative estimates; set pricing_file in the provider configuration for accurate figures.
+func defaultPricingTable() *pricingTable {
+	return &pricingTable{
+		InferenceTypes: map[string]float64{
+			"marqo.CPU.small": 0.05,
+			"marqo.CPU.large": 0.16,
+			"marqo.GPU":       0.98,
+		},
+		StorageClasses: map[string]float64{
+			"marqo.basic":       0.03,
+			"marqo.balanced":    0.37,
+			"marqo.performance": 0.83,
+		},
+	}
+}
+
+// loadPricingTable reads a JSON pricing file and merges it over the default prices,
+// so a file only needs to list the prices it changes.
+func loadPricingTable(filename string) (*pricingTable, error) {
+	pricing := defaultPricingTable()
+	if filename == "" {
+		return pricing, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading pricing file: %w", err)
+	}
+
+	var overrides pricingTable
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("error parsing pricing file %s: %w", filename, err)
+	}
+
+	for name, price := range overrides.InferenceTypes {
+		pricing.InferenceTypes[name] = price
+	}
+	for name, price := range overrides.StorageClasses {
+		pricing.StorageClasses[name] = price
+	}
+	return pricing, nil
+}
+
+// hourlyCost estimates the hourly cost of an index with the given settings. It reports false
+// when a price is missing or a setting is not known yet.
+func (p *pricingTable) hourlyCost(settings IndexSettingsModel) (float64, bool) {
+	if p == nil || settings.NumberOfInferences.IsUnknown() || settings.NumberOfShards.IsUnknown() || settings.NumberOfReplicas.IsUnknown() {
+		return 0, false
+	}
+
+	normalizeIndexSettings(&settings)
+	inferencePrice, inferenceFound := p.InferenceTypes[settings.InferenceType.ValueString()]
+	storagePrice, storageFound := p.StorageClasses[settings.StorageClass.ValueString()]
+	if !inferenceFound || !storageFound {
+		return 0, false
+	}
+
+	shardCopies := settings.NumberOfShards.ValueInt64() * (settings.NumberOfReplicas.ValueInt64() + 1)
+	return inferencePrice*float64(settings.NumberOfInferences.ValueInt64()) + storagePrice*float64(shardCopies), true
+}
+
+// Rough figures used to estimate how long a scaling operation takes. Inference changes replace
+// the inference nodes, while shard and replica changes copy every document.
+const (
+	inferenceChangeDuration = 10 * time.Minute
+	storageChangeDuration   = 10 * time.Minute
+	docsCopiedPerMinute     = 50000
+)
+
+// estimateScalingDuration estimates how long Marqo takes to move an index from one set of
+// settings to another, given the number of documents in the index.
+func estimateScalingDuration(docsCount int64, from IndexSettingsModel, to IndexSettingsModel) time.Duration {
+	var duration time.Duration
+
+	if !from.InferenceType.Equal(to.InferenceType) || !from.NumberOfInferences.Equal(to.NumberOfInferences) {
+		duration += inferenceChangeDuration
+	}
+
+	// Every added shard copy is filled with its share of the documents
+	addedCopies := to.NumberOfShards.ValueInt64()*(to.NumberOfReplicas.ValueInt64()+1) -
+		from.NumberOfShards.ValueInt64()*(from.NumberOfReplicas.ValueInt64()+1)
+	if addedCopies > 0 && to.NumberOfShards.ValueInt64() > 0 {
+		docsCopied := docsCount * addedCopies / to.NumberOfShards.ValueInt64()
+		duration += storageChangeDuration + time.Duration(docsCopied)*time.Minute/docsCopiedPerMinute
+	}
+
+	return duration.Round(time.Minute)
+}
+
+// scalingOperationSummary describes the scaling settings that change between the prior state and
+// the plan, with estimates of the duration and the hourly cost delta. It returns an empty string
+// when no scaling setting changes.
+func scalingOperationSummary(current *go_marqo.IndexDetail, prior IndexSettingsModel, planned IndexSettingsModel, pricing *pricingTable) string {
+	normalizeIndexSettings(&prior)
+	normalizeIndexSettings(&planned)
+
+	var changes []string
+	changes = appendScalingChange(changes, "inference_type", prior.InferenceType, planned.InferenceType)
+	changes = appendScalingChange(changes, "number_of_inferences", prior.NumberOfInferences, planned.NumberOfInferences)
+	changes = appendScalingChange(changes, "number_of_shards", prior.NumberOfShards, planned.NumberOfShards)
+	changes = appendScalingChange(changes, "number_of_replicas", prior.NumberOfReplicas, planned.NumberOfReplicas)
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("Index %s will be scaled:\n%s\n\n", current.IndexName, strings.Join(changes, "\n")))
+
+	docsCount, err := strconv.ParseInt(current.DocsCount, 10, 64)
+	if err != nil {
+		summary.WriteString(fmt.Sprintf("Estimated duration: at least %s; the document count is not available.\n",
+			estimateScalingDuration(0, prior, planned)))
+	} else {
+		summary.WriteString(fmt.Sprintf("Estimated duration: about %s for %d documents.\n",
+			estimateScalingDuration(docsCount, prior, planned), docsCount))
+	}
+
+	priorCost, priorKnown := pricing.hourlyCost(prior)
+	plannedCost, plannedKnown := pricing.hourlyCost(planned)
+	switch {
+	case !priorKnown || !plannedKnown:
+		summary.WriteString("Estimated hourly cost: unknown; add the missing prices to the provider pricing_file.")
+	case priorCost > 0:
+		summary.WriteString(fmt.Sprintf("Estimated hourly cost: $%.2f -> $%.2f (%+.0f%%).",
+			priorCost, plannedCost, (plannedCost-priorCost)/priorCost*100))
+	default:
+		summary.WriteString(fmt.Sprintf("Estimated hourly cost: $%.2f -> $%.2f.", priorCost, plannedCost))
+	}
+
+	return summary.String()
+}
+
+// appendScalingChange records a scaling setting whose planned value differs from the prior one.
+func appendScalingChange(changes []string, name string, prior attr.Value, planned attr.Value) []string {
+	if planned.Equal(prior) {
+		return changes
+	}
+	return append(changes, fmt.Sprintf("  %s: %s -> %s", name, prior, planned))
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"marqo/go_marqo"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLoadPricingTable(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "pricing.json")
+	if err := os.WriteFile(filename, []byte(`{"inference_types": {"marqo.GPU": 2.5}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pricing, err := loadPricingTable(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pricing.InferenceTypes["marqo.GPU"] != 2.5 {
+		t.Errorf("expected overridden GPU price, got %v", pricing.InferenceTypes["marqo.GPU"])
+	}
+	if pricing.StorageClasses["marqo.basic"] != defaultPricingTable().StorageClasses["marqo.basic"] {
+		t.Errorf("expected default basic storage price to be kept")
+	}
+
+	if _, err := loadPricingTable(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing pricing file")
+	}
+}
+
+func TestScalingOperationSummary(t *testing.T) {
+	prior := IndexSettingsModel{
+		InferenceType:      types.StringValue("marqo.CPU.small"),
+		NumberOfInferences: types.Int64Value(1),
+		StorageClass:       types.StringValue("marqo.basic"),
+		NumberOfShards:     types.Int64Value(1),
+		NumberOfReplicas:   types.Int64Value(0),
+	}
+	current := &go_marqo.IndexDetail{IndexName: "test-index", DocsCount: "500000"}
+
+	if summary := scalingOperationSummary(current, prior, prior, defaultPricingTable()); summary != "" {
+		t.Errorf("expected no summary without changes, got %q", summary)
+	}
+
+	planned := prior
+	planned.InferenceType = types.StringValue("GPU")
+	planned.NumberOfReplicas = types.Int64Value(1)
+
+	// 10m for the inference change, plus 10m and 500000 documents copied at 50000 per minute
+	if duration := estimateScalingDuration(500000, prior, planned); duration != 30*time.Minute {
+		t.Errorf("expected 30m, got %s", duration)
+	}
+
+	summary := scalingOperationSummary(current, prior, planned, defaultPricingTable())
+	for _, expected := range []string{
+		`inference_type: "marqo.CPU.small" -> "marqo.GPU"`,
+		"number_of_replicas: 0 -> 1",
+		"about 30m0s for 500000 documents",
+		"$0.08 -> $1.04 (+1200%)",
+	} {
+		if !strings.Contains(summary, expected) {
+			t.Errorf("expected summary to contain %q, got:\n%s", expected, summary)
+		}
+	}
+
+	planned.StorageClass = types.StringValue("marqo.custom")
+	if summary := scalingOperationSummary(current, prior, planned, defaultPricingTable()); !strings.Contains(summary, "Estimated hourly cost: unknown") {
+		t.Errorf("expected unknown cost for an unpriced storage class, got:\n%s", summary)
+	}
+}